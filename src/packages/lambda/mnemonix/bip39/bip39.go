@@ -17,6 +17,7 @@ import (
 	"strings"
 
 	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
 )
 
 var (
@@ -45,6 +46,11 @@ var (
 		21: big.NewInt(2),
 	}
 
+	// WordLists maps BIP39 language names to their word lists.
+	WordLists = map[string][]string{
+		"english": English,
+	}
+
 	// wordList is the set of words to use.
 	wordList []string
 
@@ -80,7 +86,7 @@ func SetWordList(list []string) {
 	wordMap = map[string]int{}
 
 	for i, v := range wordList {
-		wordMap[v] = i
+		wordMap[Normalize(v)] = i
 	}
 }
 
@@ -91,7 +97,7 @@ func GetWordList() []string {
 
 // GetWordIndex gets word index in wordMap.
 func GetWordIndex(word string) (int, bool) {
-	idx, ok := wordMap[word]
+	idx, ok := wordMap[Normalize(word)]
 	return idx, ok
 }
 
@@ -217,7 +223,7 @@ func NewMnemonic(entropy []byte) (string, error) {
 // An error is returned if the mnemonic is invalid.
 func MnemonicToByteArray(mnemonic string, raw ...bool) ([]byte, error) {
 	var (
-		mnemonicSlice   = strings.Split(mnemonic, " ")
+		mnemonicSlice   = strings.Fields(Normalize(mnemonic))
 		entropyBitSize  = len(mnemonicSlice) * 11
 		checksumBitSize = entropyBitSize % 32
 		fullByteSize    = (entropyBitSize-checksumBitSize)/8 + 1
@@ -250,11 +256,18 @@ func NewSeedWithErrorChecking(mnemonic string, password string) ([]byte, error)
 }
 
 // NewSeed creates a hashed seed output given a provided string and password.
+// Both are NFKD normalized first, as the BIP39 spec requires.
 // No checking is performed to validate that the string provided is a valid mnemonic.
 func NewSeed(mnemonic string, password string) []byte {
+	mnemonic, password = Normalize(mnemonic), Normalize(password)
 	return pbkdf2.Key([]byte(mnemonic), []byte("mnemonic"+password), 2048, 64, sha512.New)
 }
 
+// Normalize returns the NFKD form of the given mnemonic or password.
+func Normalize(s string) string {
+	return norm.NFKD.String(s)
+}
+
 // IsMnemonicValid attempts to verify that the provided mnemonic is valid.
 // Validity is determined by both the number of words being appropriate,
 // and that all the words in the mnemonic are present in the word list.
@@ -339,8 +352,8 @@ func compareByteSlices(a, b []byte) bool {
 }
 
 func splitMnemonicWords(mnemonic string) ([]string, bool) {
-	// Create a list of all the words in the mnemonic sentence
-	words := strings.Fields(mnemonic)
+	// Create a list of all the words in the normalized mnemonic sentence
+	words := strings.Fields(Normalize(mnemonic))
 
 	// Get num of words
 	numOfWords := len(words)
@@ -0,0 +1,61 @@
+package bip39
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSeedNormalizesUnicode(t *testing.T) {
+	const abandonAbout = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	tests := map[string]struct {
+		mnemonic     string
+		password     string
+		expectedSeed string
+	}{
+		"composed accents": {
+			mnemonic:     abandonAbout,
+			password:     "pässwörd",
+			expectedSeed: "f159596e1a257152783ecca3910131fb6496ae4616d76f9b4e060d0e2fead51e2ab2af2c4bb340ce6c683466324af2654b9e31bc05c93ad05025c46a83424485",
+		},
+		"decomposed accents": {
+			mnemonic:     abandonAbout,
+			password:     "pa\u0308sswo\u0308rd",
+			expectedSeed: "f159596e1a257152783ecca3910131fb6496ae4616d76f9b4e060d0e2fead51e2ab2af2c4bb340ce6c683466324af2654b9e31bc05c93ad05025c46a83424485",
+		},
+		"full-width is compatibility equal to ascii": {
+			mnemonic:     abandonAbout,
+			password:     "ｐａｓｓｗｏｒｄ",
+			expectedSeed: "319a85221b9c4ce9a2896ed8cb2c0ea205c44c512d9214c9d80e663b8aa97f09e5e4810e2d85ef57d770279d2aea6d3f0e448de3a8e0c1ea9db691d0cb6bc681",
+		},
+		"ascii": {
+			mnemonic:     abandonAbout,
+			password:     "password",
+			expectedSeed: "319a85221b9c4ce9a2896ed8cb2c0ea205c44c512d9214c9d80e663b8aa97f09e5e4810e2d85ef57d770279d2aea6d3f0e448de3a8e0c1ea9db691d0cb6bc681",
+		},
+		"japanese mnemonic with ideographic spaces": {
+			mnemonic:     "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら",
+			password:     "㍍ガバヴァぱばぐゞちぢ十人十色",
+			expectedSeed: "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			seed := NewSeed(test.mnemonic, test.password)
+			assert.Equal(t, test.expectedSeed, hex.EncodeToString(seed))
+		})
+	}
+}
+
+func TestWordLookupIsNormalized(t *testing.T) {
+	idx, ok := GetWordIndex("ａｂａｎｄｏｎ")
+	assert.True(t, ok)
+	assert.Equal(t, 0, idx)
+
+	// U+3000 ideographic space separates words
+	_, err := EntropyFromMnemonic("abandon　abandon　abandon　abandon　abandon　abandon　abandon　abandon　abandon　abandon　abandon　about")
+	assert.NoError(t, err)
+}
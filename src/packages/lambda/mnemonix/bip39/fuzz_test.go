@@ -0,0 +1,63 @@
+package bip39
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func FuzzMnemonicRoundTrip(f *testing.F) {
+	f.Add(make([]byte, 16))
+	f.Add(bytes.Repeat([]byte{0x7f}, 20))
+	f.Add(bytes.Repeat([]byte{0xff}, 32))
+	f.Add([]byte{0x01, 0x02, 0x03})
+
+	f.Fuzz(func(t *testing.T, entropy []byte) {
+		mnemonic, err := NewMnemonic(entropy)
+		if validateEntropyBitSize(len(entropy)*8) != nil {
+			if err == nil {
+				t.Fatalf("entropy of %d bytes accepted", len(entropy))
+			}
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		words := strings.Fields(mnemonic)
+		if len(words) != len(entropy)/4*3 {
+			t.Fatalf("%d bytes of entropy encoded in %d words", len(entropy), len(words))
+		}
+		if !IsMnemonicValid(mnemonic) {
+			t.Fatalf("generated mnemonic '%s' is invalid", mnemonic)
+		}
+		decoded, err := EntropyFromMnemonic(mnemonic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(entropy, decoded) {
+			t.Fatalf("round-trip mismatch %x != %x", entropy, decoded)
+		}
+	})
+}
+
+func FuzzEntropyFromMnemonic(f *testing.F) {
+	f.Add("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	f.Add("zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo")
+	f.Add("abandon")
+
+	f.Fuzz(func(t *testing.T, mnemonic string) {
+		entropy, err := EntropyFromMnemonic(mnemonic)
+		if err != nil {
+			return
+		}
+		// valid mnemonic re-encodes to itself, modulo whitespace and normalization
+		encoded, err := NewMnemonic(entropy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if encoded != strings.Join(strings.Fields(Normalize(mnemonic)), " ") {
+			t.Fatalf("'%s' re-encoded as '%s'", mnemonic, encoded)
+		}
+	})
+}
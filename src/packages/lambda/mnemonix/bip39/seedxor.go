@@ -0,0 +1,75 @@
+package bip39
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SeedXor parts, Coldcard accepts 2 to 4 parts.
+const (
+	MinXorParts = 2
+	MaxXorParts = 4
+)
+
+// ErrXorPartsLengthMismatch is returned when Seed XOR parts differ in length.
+var ErrXorPartsLengthMismatch = errors.New("Seed XOR parts must have the same number of words")
+
+// SplitXor splits the mnemonic into parts whose entropy XORs to the entropy of
+// the mnemonic, as Coldcard's Seed XOR does. Each part is a valid mnemonic of
+// the same length, all but the last part are random.
+func SplitXor(mnemonic string, parts int) ([]string, error) {
+	if parts < MinXorParts || parts > MaxXorParts {
+		return nil, fmt.Errorf("Seed XOR parts must be between %d and %d", MinXorParts, MaxXorParts)
+	}
+	entropy, err := EntropyFromMnemonic(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonics := make([]string, parts)
+	last := append([]byte{}, entropy...)
+	for i := 0; i < parts-1; i++ {
+		part, err := NewEntropy(len(entropy) * 8)
+		if err != nil {
+			return nil, err
+		}
+		xorBytes(last, part)
+		if mnemonics[i], err = NewMnemonic(part); err != nil {
+			return nil, err
+		}
+	}
+	if mnemonics[parts-1], err = NewMnemonic(last); err != nil {
+		return nil, err
+	}
+	return mnemonics, nil
+}
+
+// CombineXor returns the mnemonic of the XORed entropy of the parts. Parts are
+// validated, a part with incorrect checksum is an error.
+func CombineXor(mnemonics []string) (string, error) {
+	if len(mnemonics) < MinXorParts {
+		return "", fmt.Errorf("at least %d Seed XOR parts are required", MinXorParts)
+	}
+	var entropy []byte
+	for i, mnemonic := range mnemonics {
+		part, err := EntropyFromMnemonic(mnemonic)
+		if err != nil {
+			return "", fmt.Errorf("part %d: %w", i+1, err)
+		}
+		if entropy == nil {
+			entropy = part
+			continue
+		}
+		if len(part) != len(entropy) {
+			return "", ErrXorPartsLengthMismatch
+		}
+		xorBytes(entropy, part)
+	}
+	return NewMnemonic(entropy)
+}
+
+func xorBytes(dst, src []byte) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
@@ -0,0 +1,69 @@
+package bip39
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitXor(t *testing.T) {
+	mnemonics := []string{
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+	}
+
+	for _, mnemonic := range mnemonics {
+		for parts := MinXorParts; parts <= MaxXorParts; parts++ {
+			split, err := SplitXor(mnemonic, parts)
+			assert.NoError(t, err)
+			assert.Len(t, split, parts)
+			for _, part := range split {
+				assert.True(t, IsMnemonicValid(part), part)
+				assert.Equal(t, len(strings.Fields(mnemonic)), len(strings.Fields(part)))
+			}
+
+			combined, err := CombineXor(split)
+			assert.NoError(t, err)
+			assert.Equal(t, mnemonic, combined)
+		}
+	}
+}
+
+func TestCombineXor(t *testing.T) {
+	const (
+		zero   = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+		ones   = "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong"
+		legal  = "legal winner thank year wave sausage worth useful legal winner thank yellow"
+		letter = "letter advice cage absurd amount doctor acoustic avoid letter advice cage above"
+	)
+
+	tests := map[string]struct {
+		mnemonics        []string
+		expectedMnemonic string
+		expectedErr      error
+	}{
+		"zero entropy is identity": {mnemonics: []string{zero, legal}, expectedMnemonic: legal},
+		"ones complement":          {mnemonics: []string{ones, legal}, expectedMnemonic: letter},
+		"self cancels":             {mnemonics: []string{legal, letter, letter}, expectedMnemonic: legal},
+		"length mismatch": {
+			mnemonics:   []string{legal, "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote"},
+			expectedErr: ErrXorPartsLengthMismatch,
+		},
+		"invalid checksum": {mnemonics: []string{legal, strings.Replace(letter, "above", "abandon", 1)}, expectedErr: ErrChecksumIncorrect},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mnemonic, err := CombineXor(test.mnemonics)
+			assert.ErrorIs(t, err, test.expectedErr)
+			assert.Equal(t, test.expectedMnemonic, mnemonic)
+		})
+	}
+
+	_, err := CombineXor([]string{legal})
+	assert.Error(t, err)
+	_, err = SplitXor(legal, 5)
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package bip39
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// VectorsFile holds the official Trezor test vectors, see
+// https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+// Each vector is [entropy, mnemonic, seed, xprv], the seed uses "TREZOR" passphrase.
+const (
+	VectorsFile       = "testdata/vectors.json"
+	VectorsPassphrase = "TREZOR"
+)
+
+func loadVectors(t *testing.T) map[string][][4]string {
+	fbytes, err := os.ReadFile(VectorsFile)
+	if err != nil {
+		t.Fatal("Cannot open vectors file", "file", VectorsFile, err)
+	}
+	vectors := map[string][][4]string{}
+	if err := json.Unmarshal(fbytes, &vectors); err != nil {
+		t.Fatal("Cannot parse vectors file", "file", VectorsFile, err)
+	}
+	return vectors
+}
+
+func TestEveryWordListHasVectors(t *testing.T) {
+	vectors := loadVectors(t)
+	for language := range WordLists {
+		assert.NotEmptyf(t, vectors[language], "no test vectors for '%s' word list", language)
+	}
+	for language := range vectors {
+		assert.Containsf(t, WordLists, language, "vectors given for unknown '%s' word list", language)
+	}
+}
+
+func TestTrezorVectorsConformance(t *testing.T) {
+	for language, vectors := range loadVectors(t) {
+		list, ok := WordLists[language]
+		if !ok {
+			continue
+		}
+
+		t.Run(language, func(t *testing.T) {
+			SetWordList(list)
+			defer SetWordList(English)
+
+			for _, vector := range vectors {
+				entropyHex, mnemonic, seedHex := vector[0], vector[1], vector[2]
+				entropy, _ := hex.DecodeString(entropyHex)
+
+				mn, err := NewMnemonic(entropy)
+				assert.NoError(t, err)
+				assert.Equal(t, mnemonic, mn, "entropy", entropyHex)
+
+				en, err := EntropyFromMnemonic(mnemonic)
+				assert.NoError(t, err)
+				assert.Equal(t, entropyHex, hex.EncodeToString(en), "mnemonic", mnemonic)
+
+				seed, err := NewSeedWithErrorChecking(mnemonic, VectorsPassphrase)
+				assert.NoError(t, err)
+				assert.Equal(t, seedHex, hex.EncodeToString(seed), "mnemonic", mnemonic)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package codex32
+
+// uint128 holds checksum residues, the long code needs 75 bits.
+type uint128 struct {
+	hi, lo uint64
+}
+
+func (a uint128) xor(b uint128) uint128 {
+	return uint128{a.hi ^ b.hi, a.lo ^ b.lo}
+}
+
+// bchCode is the BCH code over GF(32) checking the data part of the string.
+type bchCode struct {
+	generator [5]uint128
+	constant  uint128
+	// shift is the residue size in bits less 5
+	shift  uint
+	length int
+}
+
+var (
+	shortCode = bchCode{
+		generator: [5]uint128{
+			{0x1, 0x9dc500ce73fde210},
+			{0x1, 0xbfae00def77fe529},
+			{0x1, 0xfbd920fffe7bee52},
+			{0x1, 0x739640bdeee3fdad},
+			{0x0, 0x7729a039cfc75f5a},
+		},
+		constant: uint128{0x1, 0x0ce0795c2fd1e62a},
+		shift:    60,
+		length:   13,
+	}
+	longCode = bchCode{
+		generator: [5]uint128{
+			{0x3d5, 0x9d273535ea62d897},
+			{0x7a9, 0xbecb6361c6c51507},
+			{0x543, 0xf9b7e6c38d8a2a0e},
+			{0x0c5, 0x77eaeccf1990d13c},
+			{0x188, 0x7f74f8dc71b10651},
+		},
+		constant: uint128{0x433, 0x81e570bf4798ab26},
+		shift:    70,
+		length:   15,
+	}
+)
+
+func (c bchCode) polymod(values []uint8) uint128 {
+	residue := uint128{0, 0x23181b3}
+	for _, v := range values {
+		var b uint64
+		if c.shift < 64 {
+			b = residue.hi<<(64-c.shift) | residue.lo>>c.shift
+			residue.hi, residue.lo = 0, residue.lo&(1<<c.shift-1)
+		} else {
+			b = residue.hi >> (c.shift - 64)
+			residue.hi &= 1<<(c.shift-64) - 1
+		}
+		residue = uint128{residue.hi<<5 | residue.lo>>59, residue.lo<<5 | uint64(v)}
+		for i := 0; i < 5; i++ {
+			if (b>>i)&1 == 1 {
+				residue = residue.xor(c.generator[i])
+			}
+		}
+	}
+	return residue
+}
+
+// createChecksum returns checksum characters of the data values.
+func (c bchCode) createChecksum(data []uint8) []uint8 {
+	values := append(append([]uint8{}, data...), make([]uint8, c.length)...)
+	polymod := c.polymod(values).xor(c.constant)
+	checksum := make([]uint8, c.length)
+	for i := range checksum {
+		shift := uint(5 * (c.length - 1 - i))
+		var v uint64
+		if shift < 64 {
+			v = polymod.lo>>shift | polymod.hi<<(64-shift)
+		} else {
+			v = polymod.hi >> (shift - 64)
+		}
+		checksum[i] = uint8(v & 31)
+	}
+	return checksum
+}
+
+func (c bchCode) verifyChecksum(data []uint8) bool {
+	return c.polymod(data) == c.constant
+}
+
+// codeForLength picks the code by the data part length, both with checksum.
+func codeForLength(n int) (bchCode, bool) {
+	switch {
+	case n <= maxShortLength:
+		return shortCode, true
+	case n >= minLongLength && n <= maxLongLength:
+		return longCode, true
+	}
+	return bchCode{}, false
+}
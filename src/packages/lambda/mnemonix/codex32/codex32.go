@@ -0,0 +1,303 @@
+// Package codex32 implements BIP-93 codex32 strings, bech32 encoded secrets
+// which can be split into k-of-n shares and checked or recovered by hand.
+package codex32
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// Charset is the bech32 alphabet, the character value is its position.
+	Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	// Prefix is the human readable part with the separator.
+	Prefix = "ms1"
+
+	// SecretIndex is the share index of the unshared secret.
+	SecretIndex = 's'
+
+	// MaxShares is the number of distinct share indexes besides the secret one.
+	MaxShares = 31
+
+	shareIndexPos  = 5
+	headerLength   = 6
+	maxShortLength = 93
+	minLongLength  = 96
+	maxLongLength  = 124
+
+	minSecretLength = 16
+	maxSecretLength = 64
+)
+
+// shareIndexes are share indexes in order they are given to the shares
+const shareIndexes = "acdefghjklmnpqrtuvwxyz023456789"
+
+var (
+	// ErrInvalidString is returned when the codex32 string is malformed.
+	ErrInvalidString = errors.New("invalid codex32 string")
+
+	// ErrChecksumIncorrect is returned when the codex32 string has the incorrect checksum.
+	ErrChecksumIncorrect = errors.New("codex32 checksum incorrect")
+
+	// ErrSecretLengthInvalid is returned when the secret has an invalid size.
+	ErrSecretLengthInvalid = fmt.Errorf("secret length must be between %d and %d bytes", minSecretLength, maxSecretLength)
+)
+
+// Share is a parsed codex32 string.
+type Share struct {
+	// Threshold is the number of shares to recover the secret, 0 for unshared secret.
+	Threshold  int
+	Identifier string
+	Index      byte
+	data       []uint8
+}
+
+// Parse validates the codex32 string and its checksum. The string is either
+// all lowercase or all uppercase.
+func Parse(s string) (*Share, error) {
+	lower := strings.ToLower(s)
+	if s != lower && s != strings.ToUpper(s) {
+		return nil, fmt.Errorf("%w: mixed case", ErrInvalidString)
+	}
+	if !strings.HasPrefix(lower, Prefix) {
+		return nil, fmt.Errorf("%w: missing %s prefix", ErrInvalidString, Prefix)
+	}
+	data, err := toValues(lower[len(Prefix):])
+	if err != nil {
+		return nil, err
+	}
+	code, ok := codeForLength(len(data))
+	if !ok || len(data) < headerLength+code.length+(minSecretLength*8+4)/5 {
+		return nil, fmt.Errorf("%w: invalid length %d", ErrInvalidString, len(s))
+	}
+	if !code.verifyChecksum(data) {
+		return nil, ErrChecksumIncorrect
+	}
+	if payload := len(data) - headerLength - code.length; payload*5%8 > 4 {
+		return nil, fmt.Errorf("%w: invalid payload length %d", ErrInvalidString, payload)
+	}
+
+	if k := lower[len(Prefix)]; k != '0' && (k < '2' || k > '9') {
+		return nil, fmt.Errorf("%w: invalid threshold '%c'", ErrInvalidString, k)
+	}
+	share := &Share{
+		Threshold:  int(lower[len(Prefix)] - '0'),
+		Identifier: lower[len(Prefix)+1 : len(Prefix)+shareIndexPos],
+		Index:      lower[len(Prefix)+shareIndexPos],
+		data:       data,
+	}
+	if share.Threshold == 0 && share.Index != SecretIndex {
+		return nil, fmt.Errorf("%w: threshold 0 requires share index '%c'", ErrInvalidString, SecretIndex)
+	}
+	return share, nil
+}
+
+// String returns the lowercase codex32 string.
+func (s *Share) String() string {
+	return Prefix + toChars(s.data)
+}
+
+// Secret decodes the payload of the secret share, the padding bits are ignored.
+func (s *Share) Secret() ([]byte, error) {
+	if s.Index != SecretIndex {
+		return nil, fmt.Errorf("share '%c' is not the secret, combine %d shares to recover it", s.Index, s.Threshold)
+	}
+	code, _ := codeForLength(len(s.data))
+	return fromValues(s.data[headerLength : len(s.data)-code.length]), nil
+}
+
+// Encode returns the unshared codex32 string of the secret. Random identifier
+// is used when it's empty.
+func Encode(identifier string, secret []byte) (string, error) {
+	share, err := newShare(0, identifier, SecretIndex, secret)
+	if err != nil {
+		return "", err
+	}
+	return share.String(), nil
+}
+
+// Split returns count shares of the secret, any threshold of them recover it.
+// As BIP-93 describes, the first threshold-1 shares are random and the others
+// are derived from them and the secret. Random identifier is used when it's
+// empty.
+func Split(identifier string, secret []byte, threshold, count int) ([]string, error) {
+	if threshold < 2 || threshold > 9 {
+		return nil, errors.New("threshold must be between 2 and 9")
+	}
+	if count < threshold || count > MaxShares {
+		return nil, fmt.Errorf("share count must be between threshold %d and %d", threshold, MaxShares)
+	}
+	if identifier == "" {
+		var err error
+		if identifier, err = randomIdentifier(); err != nil {
+			return nil, err
+		}
+	}
+
+	secretShare, err := newShare(threshold, identifier, SecretIndex, secret)
+	if err != nil {
+		return nil, err
+	}
+	points := [][]uint8{secretShare.data}
+	shares := make([]string, 0, count)
+	for i := 0; i < threshold-1; i++ {
+		random, err := randomBytes(len(secret))
+		if err != nil {
+			return nil, err
+		}
+		share, err := newShare(threshold, identifier, shareIndexes[i], random)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, share.data)
+		shares = append(shares, share.String())
+	}
+	for i := threshold - 1; i < count; i++ {
+		shares = append(shares, Prefix+toChars(interpolate(points, charValue(shareIndexes[i]))))
+	}
+	return shares, nil
+}
+
+// Combine recovers the secret from the codex32 strings. The secret share is
+// used as is, otherwise threshold shares of distinct indexes are required.
+func Combine(strs []string) ([]byte, error) {
+	if len(strs) == 0 {
+		return nil, errors.New("no codex32 strings given")
+	}
+	shares := make([]*Share, 0, len(strs))
+	seen := map[byte]bool{}
+	for _, str := range strs {
+		share, err := Parse(strings.TrimSpace(str))
+		if err != nil {
+			return nil, err
+		}
+		if share.Index == SecretIndex {
+			return share.Secret()
+		}
+		first := share
+		if len(shares) > 0 {
+			first = shares[0]
+		}
+		if share.Threshold != first.Threshold || share.Identifier != first.Identifier || len(share.data) != len(first.data) {
+			return nil, errors.New("shares have different threshold, identifier or length")
+		}
+		if !seen[share.Index] {
+			seen[share.Index] = true
+			shares = append(shares, share)
+		}
+	}
+
+	threshold := shares[0].Threshold
+	if len(shares) < threshold {
+		return nil, fmt.Errorf("%d distinct shares given, %d required", len(shares), threshold)
+	}
+	points := make([][]uint8, threshold)
+	for i := range points {
+		points[i] = shares[i].data
+	}
+	secret := &Share{Threshold: threshold, Identifier: shares[0].Identifier, Index: SecretIndex, data: interpolate(points, charValue(SecretIndex))}
+	return secret.Secret()
+}
+
+func newShare(threshold int, identifier string, index byte, secret []byte) (*Share, error) {
+	if len(secret) < minSecretLength || len(secret) > maxSecretLength {
+		return nil, ErrSecretLengthInvalid
+	}
+	if identifier == "" {
+		var err error
+		if identifier, err = randomIdentifier(); err != nil {
+			return nil, err
+		}
+	}
+	identifier = strings.ToLower(identifier)
+	header, err := toValues(fmt.Sprintf("%d%s%c", threshold, identifier, index))
+	if err != nil || len(identifier) != 4 {
+		return nil, fmt.Errorf("invalid identifier '%s', expected 4 bech32 characters", identifier)
+	}
+
+	data := append(header, toValues5(secret)...)
+	code := shortCode
+	if len(data)+shortCode.length > maxShortLength {
+		code = longCode
+	}
+	return &Share{
+		Threshold:  threshold,
+		Identifier: identifier,
+		Index:      index,
+		data:       append(data, code.createChecksum(data)...),
+	}, nil
+}
+
+func randomIdentifier() (string, error) {
+	b, err := randomBytes(4)
+	if err != nil {
+		return "", err
+	}
+	id := make([]uint8, len(b))
+	for i := range b {
+		id[i] = b[i] & 31
+	}
+	return toChars(id), nil
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	return b, err
+}
+
+func charValue(c byte) uint8 {
+	return uint8(strings.IndexByte(Charset, c))
+}
+
+func toValues(s string) ([]uint8, error) {
+	values := make([]uint8, len(s))
+	for i := range s {
+		v := strings.IndexByte(Charset, s[i])
+		if v < 0 {
+			return nil, fmt.Errorf("%w: invalid character '%c'", ErrInvalidString, s[i])
+		}
+		values[i] = uint8(v)
+	}
+	return values, nil
+}
+
+func toChars(values []uint8) string {
+	var sb strings.Builder
+	for _, v := range values {
+		sb.WriteByte(Charset[v])
+	}
+	return sb.String()
+}
+
+// toValues5 regroups bytes into 5 bit values, padded with zero bits.
+func toValues5(b []byte) []uint8 {
+	values := make([]uint8, 0, (len(b)*8+4)/5)
+	acc, bits := uint(0), uint(0)
+	for _, x := range b {
+		acc = acc<<8 | uint(x)
+		for bits += 8; bits >= 5; bits -= 5 {
+			values = append(values, uint8(acc>>(bits-5)&31))
+		}
+	}
+	if bits > 0 {
+		values = append(values, uint8(acc<<(5-bits)&31))
+	}
+	return values
+}
+
+// fromValues regroups 5 bit values into bytes, dropping the padding bits.
+func fromValues(values []uint8) []byte {
+	b := make([]byte, 0, len(values)*5/8)
+	acc, bits := uint(0), uint(0)
+	for _, v := range values {
+		acc = acc<<5 | uint(v)
+		for bits += 5; bits >= 8; bits -= 8 {
+			b = append(b, byte(acc>>(bits-8)))
+		}
+	}
+	return b
+}
@@ -0,0 +1,131 @@
+package codex32
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test vectors of BIP-93, see
+// https://github.com/bitcoin/bips/blob/master/bip-0093.mediawiki#test-vectors
+const (
+	vector1       = "ms10testsxxxxxxxxxxxxxxxxxxxxxxxxxx4nzvca9cmczlw"
+	vector1Secret = "318c6318c6318c6318c6318c6318c631"
+	vector2A      = "MS12NAMEA320ZYXWVUTSRQPNMLKJHGFEDCAXRPP870HKKQRM"
+	vector2C      = "MS12NAMECACDEFGHJKLMNPQRSTUVWXYZ023FTR2GDZMPY6PN"
+	vector2S      = "MS12NAMES6XQGUZTTXKEQNJSJZV4JV3NZ5K3KWGSPHUH6EVW"
+	vector2Secret = "d1808e096b35b209ca12132b264662a5"
+	vector3S      = "ms13cashsllhdmn9m42vcsamx24zrxgs3qqjzqud4m0d6nln"
+	vector3Secret = "ffeeddccbbaa99887766554433221100"
+	vector5       = "MS100C8VSM32ZXFGUHPCHTLUPZRY9X8GF2TVDW0S3JN54KHCE6MUA7LQPZYGSFJD6AN074RXVCEMLH8WU3TK925ACDEFGHJKLMNPQRSTUVWXY06FHPV80UNDVARHRAK"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		str               string
+		expectedThreshold int
+		expectedID        string
+		expectedIndex     byte
+		expectedErr       error
+	}{
+		"vector 1":          {str: vector1, expectedThreshold: 0, expectedID: "test", expectedIndex: 's'},
+		"vector 2 share":    {str: vector2A, expectedThreshold: 2, expectedID: "name", expectedIndex: 'a'},
+		"vector 3":          {str: vector3S, expectedThreshold: 3, expectedID: "cash", expectedIndex: 's'},
+		"vector 5 long":     {str: vector5, expectedThreshold: 0, expectedID: "0c8v", expectedIndex: 's'},
+		"invalid checksum":  {str: vector1[:len(vector1)-1] + "q", expectedErr: ErrChecksumIncorrect},
+		"mixed case":        {str: "MS1" + vector1[3:], expectedErr: ErrInvalidString},
+		"invalid character": {str: strings.Replace(vector1, "x", "b", 1), expectedErr: ErrInvalidString},
+		"other prefix":      {str: "mx" + vector1[2:], expectedErr: ErrInvalidString},
+		"too short":         {str: vector1[:20], expectedErr: ErrInvalidString},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			share, err := Parse(test.str)
+			assert.ErrorIs(t, err, test.expectedErr)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.expectedThreshold, share.Threshold)
+			assert.Equal(t, test.expectedID, share.Identifier)
+			assert.Equal(t, test.expectedIndex, share.Index)
+			assert.Equal(t, strings.ToLower(test.str), share.String())
+		})
+	}
+}
+
+func TestCombineVectors(t *testing.T) {
+	tests := map[string]struct {
+		strs           []string
+		expectedSecret string
+	}{
+		"vector 1 unshared": {strs: []string{vector1}, expectedSecret: vector1Secret},
+		"vector 2 shares":   {strs: []string{vector2A, vector2C}, expectedSecret: vector2Secret},
+		"vector 2 secret":   {strs: []string{vector2S}, expectedSecret: vector2Secret},
+		"vector 3 secret":   {strs: []string{vector3S}, expectedSecret: vector3Secret},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			secret, err := Combine(test.strs)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedSecret, hex.EncodeToString(secret))
+		})
+	}
+}
+
+func TestInterpolateSecretShare(t *testing.T) {
+	a, _ := Parse(vector2A)
+	c, _ := Parse(vector2C)
+	s := interpolate([][]uint8{a.data, c.data}, charValue(SecretIndex))
+	assert.Equal(t, strings.ToLower(vector2S), Prefix+toChars(s))
+}
+
+func TestEncode(t *testing.T) {
+	for _, size := range []int{16, 32, 46, 47, 64} {
+		secret, _ := randomBytes(size)
+		str, err := Encode("", secret)
+		assert.NoError(t, err, size)
+		share, err := Parse(str)
+		assert.NoError(t, err, size)
+		decoded, _ := share.Secret()
+		assert.Equal(t, secret, decoded, size)
+	}
+
+	_, err := Encode("test", make([]byte, 15))
+	assert.ErrorIs(t, err, ErrSecretLengthInvalid)
+	_, err = Encode("tes", make([]byte, 16))
+	assert.Error(t, err)
+}
+
+func TestSplitAndCombine(t *testing.T) {
+	secret, _ := hex.DecodeString(vector2Secret)
+	shares, err := Split("name", secret, 3, 5)
+	assert.NoError(t, err)
+	assert.Len(t, shares, 5)
+	for _, str := range shares {
+		assert.True(t, strings.HasPrefix(str, "ms13name"), str)
+		_, err := Parse(str)
+		assert.NoError(t, err, str)
+	}
+
+	for _, picked := range [][]string{shares[:3], shares[2:], {shares[4], shares[0], shares[3], shares[0]}} {
+		recovered, err := Combine(picked)
+		assert.NoError(t, err)
+		assert.Equal(t, secret, recovered)
+	}
+
+	_, err = Combine(shares[:2])
+	assert.Error(t, err)
+	_, err = Combine([]string{shares[0], shares[0], shares[1]})
+	assert.Error(t, err)
+
+	_, err = Split("", secret, 1, 3)
+	assert.Error(t, err)
+	_, err = Split("", secret, 3, 2)
+	assert.Error(t, err)
+	_, err = Split("", secret, 2, 32)
+	assert.Error(t, err)
+}
@@ -0,0 +1,54 @@
+package codex32
+
+// gf32Exp and gf32Log tables of GF(32) modulo x^5 + x^3 + 1, generator x, as
+// bech32 characters are the field elements.
+var (
+	gf32Exp [31]uint8
+	gf32Log [32]uint8
+)
+
+func init() {
+	x := uint8(1)
+	for i := range gf32Exp {
+		gf32Exp[i] = x
+		gf32Log[x] = uint8(i)
+		x <<= 1
+		if x&32 != 0 {
+			x ^= 0x29
+		}
+	}
+}
+
+func gf32Mul(a, b uint8) uint8 {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gf32Exp[(int(gf32Log[a])+int(gf32Log[b]))%31]
+}
+
+func gf32Div(a, b uint8) uint8 {
+	if a == 0 {
+		return 0
+	}
+	return gf32Exp[(int(gf32Log[a])+31-int(gf32Log[b]))%31]
+}
+
+// interpolate evaluates at x the polynomial passing through the shares,
+// character by character. Share indexes are at the position of share index of
+// the data part and they must be distinct.
+func interpolate(shares [][]uint8, x uint8) []uint8 {
+	result := make([]uint8, len(shares[0]))
+	for i, share := range shares {
+		weight := uint8(1)
+		for j, other := range shares {
+			if i != j {
+				xi, xj := share[shareIndexPos], other[shareIndexPos]
+				weight = gf32Mul(weight, gf32Div(x^xj, xi^xj))
+			}
+		}
+		for k, v := range share {
+			result[k] ^= gf32Mul(weight, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pnowosie/complete-mnemonic/electrum"
+)
+
+// newElectrumSeed generates Electrum seed of the requested type.
+func newElectrumSeed(in Request) *Response {
+	mnemonic, err := electrum.NewMnemonic(in.SeedType)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	in.Mnemonic = mnemonic
+	return checkElectrumSeed(in)
+}
+
+// checkElectrumSeed detects the format of the words and derives the seed of the
+// Electrum ones. Words valid in both formats get a warning.
+func checkElectrumSeed(in Request) *Response {
+	mnemonic := electrum.Normalize(strings.ReplaceAll(in.Mnemonic, "_", " "))
+	detected := electrum.Detect(mnemonic)
+	if !detected.BIP39 && detected.Electrum == "" {
+		return errorResponse(http.StatusBadRequest, fmt.Errorf("words are neither Electrum seed nor BIP-39 mnemonic"))
+	}
+
+	body := &ElectrumBody{
+		Type:       detected.Electrum,
+		BIP39:      detected.BIP39,
+		Derivation: electrum.Derivation(detected.Electrum),
+	}
+	if detected.Electrum == electrum.SeedOld {
+		seed, _ := electrum.OldSeedFromMnemonic(mnemonic)
+		body.Seed = seed
+		body.MasterKey = hex.EncodeToString(electrum.OldMasterKey(seed))
+	} else if detected.Electrum != "" {
+		body.Seed = hex.EncodeToString(electrum.NewSeed(mnemonic, in.Passphrase))
+	}
+
+	resp := &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Mnemonic: mnemonic,
+			Length:   len(strings.Fields(mnemonic)),
+			Electrum: body,
+		},
+	}
+	if detected.Ambiguous() {
+		resp.Body.Warning = AmbiguousSeedWarning
+	}
+	return resp
+}
@@ -0,0 +1,23 @@
+package electrum
+
+import "github.com/pnowosie/complete-mnemonic/bip39"
+
+// Detection tells which seed formats the words are valid in.
+type Detection struct {
+	BIP39    bool
+	Electrum string
+}
+
+// Detect checks the words both as BIP-39 mnemonic and Electrum seed.
+func Detect(mnemonic string) Detection {
+	return Detection{
+		BIP39:    bip39.IsMnemonicValid(mnemonic),
+		Electrum: SeedType(mnemonic),
+	}
+}
+
+// Ambiguous tells the words are valid in both formats, so wallets would derive
+// different keys from them.
+func (d Detection) Ambiguous() bool {
+	return d.BIP39 && d.Electrum != ""
+}
@@ -0,0 +1,232 @@
+package electrum
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pnowosie/complete-mnemonic/bip39"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Seed types, old v1 seeds have no version.
+const (
+	SeedOld       = "old"
+	SeedStandard  = "standard"
+	SeedSegwit    = "segwit"
+	Seed2FA       = "2fa"
+	Seed2FASegwit = "2fa_segwit"
+)
+
+const (
+	seedVersionKey = "Seed version"
+	seedSalt       = "electrum"
+	seedIterations = 2048
+
+	// seedBits is the entropy of new seeds, 12 words of the BIP-39 wordlist
+	seedBits = 132
+
+	oldSeedStretching = 100000
+)
+
+// seedPrefixes are prefixes of the hex HMAC of the seed words by seed type.
+var seedPrefixes = map[string]string{
+	SeedStandard:  "01",
+	SeedSegwit:    "100",
+	Seed2FA:       "101",
+	Seed2FASegwit: "102",
+}
+
+// derivations are paths of the receiving addresses by seed type.
+var derivations = map[string]string{
+	SeedOld:      "m/0/",
+	SeedStandard: "m/0/",
+	SeedSegwit:   "m/0'/0/",
+}
+
+var (
+	// ErrSeedTypeInvalid is returned when new seed of unknown type is requested.
+	ErrSeedTypeInvalid = fmt.Errorf("seed type must be one of: %s, %s, %s, %s", SeedStandard, SeedSegwit, Seed2FA, Seed2FASegwit)
+
+	// ErrInvalidOldSeed is returned when the words are not an old v1 seed.
+	ErrInvalidOldSeed = errors.New("Invalid old seed")
+)
+
+// Normalize prepares the seed words as Electrum does: NFKD, lower case without
+// accents, single spaces and no spaces between CJK characters.
+func Normalize(s string) string {
+	s = strings.ToLower(norm.NFKD.String(s))
+	s = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, s)
+	s = strings.Join(strings.Fields(s), " ")
+
+	runes := []rune(s)
+	var sb strings.Builder
+	for i, r := range runes {
+		if r == ' ' && isCJK(runes[i-1]) && isCJK(runes[i+1]) {
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) ||
+		(r >= 0x3000 && r <= 0x303f) || (r >= 0x3200 && r <= 0x33ff) || (r >= 0xff00 && r <= 0xffef)
+}
+
+// SeedType returns the type of the seed or empty string when the words are not
+// an Electrum seed.
+func SeedType(mnemonic string) string {
+	words := len(strings.Fields(Normalize(mnemonic)))
+	switch {
+	case IsOldSeed(mnemonic):
+		return SeedOld
+	case isNewSeed(mnemonic, SeedStandard):
+		return SeedStandard
+	case isNewSeed(mnemonic, SeedSegwit):
+		return SeedSegwit
+	case isNewSeed(mnemonic, Seed2FA) && (words == 12 || words >= 20):
+		return Seed2FA
+	case isNewSeed(mnemonic, Seed2FASegwit) && (words == 12 || words >= 20):
+		return Seed2FASegwit
+	}
+	return ""
+}
+
+func isNewSeed(mnemonic, seedType string) bool {
+	mac := hmac.New(sha512.New, []byte(seedVersionKey))
+	mac.Write([]byte(Normalize(mnemonic)))
+	return strings.HasPrefix(hex.EncodeToString(mac.Sum(nil)), seedPrefixes[seedType])
+}
+
+// IsOldSeed tells whether the words are 12 or 24 words of the old wordlist.
+func IsOldSeed(mnemonic string) bool {
+	_, err := OldSeedFromMnemonic(mnemonic)
+	return err == nil
+}
+
+// Derivation returns the path of receiving addresses of the seed type, 2fa
+// seeds are co-signed and have none.
+func Derivation(seedType string) string {
+	return derivations[seedType]
+}
+
+// NewMnemonic generates a new seed of the type. Seeds which happen to be old
+// seeds or valid BIP-39 mnemonics are skipped, like Electrum does.
+func NewMnemonic(seedType string) (string, error) {
+	if _, ok := seedPrefixes[seedType]; !ok {
+		return "", ErrSeedTypeInvalid
+	}
+	var (
+		wordBits = int64(11)
+		entropy  = new(big.Int)
+		lowest   = new(big.Int).Lsh(big.NewInt(1), uint(seedBits-wordBits))
+		limit    = new(big.Int).Lsh(big.NewInt(1), seedBits)
+		err      error
+	)
+	// the top word must not be the first word of the list
+	for entropy.Cmp(lowest) < 0 {
+		if entropy, err = rand.Int(rand.Reader, limit); err != nil {
+			return "", err
+		}
+	}
+
+	for nonce := int64(1); ; nonce++ {
+		mnemonic := encodeNumber(new(big.Int).Add(entropy, big.NewInt(nonce)))
+		if IsOldSeed(mnemonic) || bip39.IsMnemonicValid(mnemonic) {
+			continue
+		}
+		if isNewSeed(mnemonic, seedType) {
+			return mnemonic, nil
+		}
+	}
+}
+
+// encodeNumber returns words of the number, the least significant word first.
+func encodeNumber(n *big.Int) string {
+	var (
+		words []string
+		size  = big.NewInt(int64(len(bip39.English)))
+		word  = new(big.Int)
+	)
+	n = new(big.Int).Set(n)
+	for n.Sign() > 0 {
+		n.DivMod(n, size, word)
+		words = append(words, bip39.English[word.Int64()])
+	}
+	return strings.Join(words, " ")
+}
+
+// NewSeed returns the BIP-32 seed of the new type seed with the passphrase.
+func NewSeed(mnemonic, passphrase string) []byte {
+	return pbkdf2.Key([]byte(Normalize(mnemonic)), []byte(seedSalt+Normalize(passphrase)), seedIterations, 64, sha512.New)
+}
+
+// OldSeedFromMnemonic returns the hex seed the old seed words encode.
+func OldSeedFromMnemonic(mnemonic string) (string, error) {
+	words := strings.Fields(Normalize(mnemonic))
+	if len(words) != 12 && len(words) != 24 {
+		return "", ErrInvalidOldSeed
+	}
+	n := len(OldWordList)
+	var sb strings.Builder
+	for i := 0; i < len(words); i += 3 {
+		var w [3]int
+		for j := range w {
+			index, ok := oldWordMap[words[i+j]]
+			if !ok {
+				return "", fmt.Errorf("%w: word `%v` not found in old wordlist", ErrInvalidOldSeed, words[i+j])
+			}
+			w[j] = index
+		}
+		x := w[0] + n*mod(w[1]-w[0], n) + n*n*mod(w[2]-w[1], n)
+		fmt.Fprintf(&sb, "%08x", x)
+	}
+	return sb.String(), nil
+}
+
+// OldMnemonic returns the old seed words of the hex seed.
+func OldMnemonic(seed string) (string, error) {
+	if _, err := hex.DecodeString(seed); err != nil || (len(seed) != 32 && len(seed) != 64) {
+		return "", fmt.Errorf("%w: expected 32 or 64 hex digits", ErrInvalidOldSeed)
+	}
+	n := uint64(len(OldWordList))
+	words := make([]string, 0, len(seed)/8*3)
+	for i := 0; i < len(seed); i += 8 {
+		x, _ := strconv.ParseUint(seed[i:i+8], 16, 32)
+		w1 := x % n
+		w2 := (x/n + w1) % n
+		w3 := (x/n/n + w2) % n
+		words = append(words, OldWordList[w1], OldWordList[w2], OldWordList[w3])
+	}
+	return strings.Join(words, " "), nil
+}
+
+// OldMasterKey stretches the hex seed into the master private key of old seeds.
+func OldMasterKey(seed string) []byte {
+	x := []byte(seed)
+	for i := 0; i < oldSeedStretching; i++ {
+		h := sha256.Sum256(append(x, seed...))
+		x = h[:]
+	}
+	return x
+}
+
+func mod(a, n int) int {
+	return ((a % n) + n) % n
+}
@@ -0,0 +1,143 @@
+package electrum
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/pnowosie/complete-mnemonic/bip39"
+	"github.com/stretchr/testify/assert"
+)
+
+// Seeds of Electrum tests, see
+// https://github.com/spesmilo/electrum/blob/master/tests/test_mnemonic.py
+const (
+	segwitSeed    = "wild father tree among universe such mobile favorite target dynamic credit identify"
+	spanishSeed   = "almíbar tibio superar vencer hacha peatón príncipe matar consejo polen vehículo odisea"
+	oldSeed       = "powerful random nobody notice nothing important anyway look away hidden message over"
+	oldSeedHex    = "acb740e454c3134901d7c8f16497cc1c"
+	ambiguousMn   = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon mass"
+	darthPlagueis = "Did you ever hear the tragedy of Darth Plagueis the Wise?"
+)
+
+func TestOldWordList(t *testing.T) {
+	assert.Len(t, OldWordList, 1626)
+	assert.Len(t, oldWordMap, 1626)
+}
+
+func TestSeedType(t *testing.T) {
+	tests := map[string]struct {
+		mnemonic     string
+		expectedType string
+	}{
+		"segwit":              {mnemonic: segwitSeed, expectedType: SeedSegwit},
+		"segwit with spacing": {mnemonic: "  Wild FATHER tree among universe such mobile favorite target dynamic credit identify\n", expectedType: SeedSegwit},
+		"standard accents":    {mnemonic: spanishSeed, expectedType: SeedStandard},
+		"standard no accents": {mnemonic: Normalize(spanishSeed), expectedType: SeedStandard},
+		"old":                 {mnemonic: oldSeed, expectedType: SeedOld},
+		"bip39":               {mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow", expectedType: ""},
+		"old words of 11":     {mnemonic: strings.TrimSuffix(oldSeed, " over"), expectedType: ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedType, SeedType(test.mnemonic))
+		})
+	}
+}
+
+func TestNewSeed(t *testing.T) {
+	tests := map[string]struct {
+		mnemonic     string
+		passphrase   string
+		expectedSeed string
+	}{
+		"segwit": {
+			mnemonic:     segwitSeed,
+			expectedSeed: "aac2a6302e48577ab4b46f23dbae0774e2e62c796f797d0a1b5faeb528301e3064342dafb79069e7c4c6b8c38ae11d7a973bec0d4f70626f8cc5184a8d0b0756",
+		},
+		"segwit with passphrase": {
+			mnemonic:     segwitSeed,
+			passphrase:   darthPlagueis,
+			expectedSeed: "4aa29f2aeb0127efb55138ab9e7be83b36750358751906f86c662b21a1ea1370f949e6d1a12fa56d3d93cadda93038c76ac8118597364e46f5156fde6183c82f",
+		},
+		"standard accents": {
+			mnemonic:     spanishSeed,
+			expectedSeed: "18bffd573a960cc775bbd80ed60b7dc00bc8796a186edebe7fc7cf1f316da0fe937852a969c5c79ded8255cdf54409537a16339fbe33fb9161af793ea47faa7a",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedSeed, hex.EncodeToString(NewSeed(test.mnemonic, test.passphrase)))
+		})
+	}
+}
+
+func TestOldSeed(t *testing.T) {
+	tests := map[string]struct {
+		mnemonic string
+		seed     string
+	}{
+		"electrum test":   {mnemonic: "hardly point goal hallway patience key stone difference ready caught listen fact", seed: "8edad31a95e7d59f8837667510d75a4d"},
+		"electrum wallet": {mnemonic: oldSeed, seed: oldSeedHex},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			seed, err := OldSeedFromMnemonic(test.mnemonic)
+			assert.NoError(t, err)
+			assert.Equal(t, test.seed, seed)
+
+			mnemonic, err := OldMnemonic(test.seed)
+			assert.NoError(t, err)
+			assert.Equal(t, test.mnemonic, mnemonic)
+		})
+	}
+
+	_, err := OldSeedFromMnemonic(segwitSeed)
+	assert.ErrorIs(t, err, ErrInvalidOldSeed)
+	_, err = OldMnemonic("acb740")
+	assert.ErrorIs(t, err, ErrInvalidOldSeed)
+	assert.Len(t, OldMasterKey(oldSeedHex), 32)
+	assert.Equal(t, OldMasterKey(oldSeedHex), OldMasterKey(oldSeedHex))
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for _, seedType := range []string{SeedStandard, SeedSegwit, Seed2FA, Seed2FASegwit} {
+		mnemonic, err := NewMnemonic(seedType)
+		assert.NoError(t, err)
+		assert.Len(t, strings.Fields(mnemonic), 12, mnemonic)
+		assert.Equal(t, seedType, SeedType(mnemonic), mnemonic)
+		assert.False(t, bip39.IsMnemonicValid(mnemonic), mnemonic)
+	}
+
+	_, err := NewMnemonic(SeedOld)
+	assert.ErrorIs(t, err, ErrSeedTypeInvalid)
+}
+
+func TestDetect(t *testing.T) {
+	tests := map[string]struct {
+		mnemonic          string
+		expected          Detection
+		expectedAmbiguous bool
+	}{
+		"electrum":  {mnemonic: segwitSeed, expected: Detection{Electrum: SeedSegwit}},
+		"bip39":     {mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow", expected: Detection{BIP39: true}},
+		"ambiguous": {mnemonic: ambiguousMn, expected: Detection{BIP39: true, Electrum: SeedStandard}, expectedAmbiguous: true},
+		"neither":   {mnemonic: "hello world", expected: Detection{}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			detected := Detect(test.mnemonic)
+			assert.Equal(t, test.expected, detected)
+			assert.Equal(t, test.expectedAmbiguous, detected.Ambiguous())
+		})
+	}
+}
+
+func TestNormalizeCJK(t *testing.T) {
+	assert.Equal(t, "あいこくしん", Normalize("あいこ くしん"))
+	assert.Equal(t, "abc def", Normalize(" ABC\tdéf "))
+}
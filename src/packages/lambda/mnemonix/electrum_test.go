@@ -0,0 +1,98 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pnowosie/complete-mnemonic/electrum"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElectrumCheck(t *testing.T) {
+	tests := map[string]struct {
+		req             *Request
+		expectedCode    int
+		expectedType    string
+		expectedBIP39   bool
+		expectedSeed    string
+		expectedWarning string
+	}{
+		"segwit": {
+			req:          &Request{Mnemonic: "wild_father_tree_among_universe_such_mobile_favorite_target_dynamic_credit_identify"},
+			expectedCode: 200,
+			expectedType: electrum.SeedSegwit,
+			expectedSeed: "aac2a6302e48577ab4b46f23dbae0774e2e62c796f797d0a1b5faeb528301e3064342dafb79069e7c4c6b8c38ae11d7a973bec0d4f70626f8cc5184a8d0b0756",
+		},
+		"old": {
+			req:          &Request{Mnemonic: "powerful random nobody notice nothing important anyway look away hidden message over"},
+			expectedCode: 200,
+			expectedType: electrum.SeedOld,
+			expectedSeed: "acb740e454c3134901d7c8f16497cc1c",
+		},
+		"bip39 only": {
+			req:           &Request{Mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+			expectedCode:  200,
+			expectedBIP39: true,
+		},
+		"both": {
+			req:             &Request{Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon mass"},
+			expectedCode:    200,
+			expectedType:    electrum.SeedStandard,
+			expectedBIP39:   true,
+			expectedWarning: AmbiguousSeedWarning,
+		},
+		"neither": {
+			req:          &Request{Mnemonic: "hello world"},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpElectrumCheck
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if resp.StatusCode != 200 {
+				return
+			}
+			assert.Equal(t, test.expectedType, resp.Body.Electrum.Type)
+			assert.Equal(t, test.expectedBIP39, resp.Body.Electrum.BIP39)
+			if test.expectedSeed != "" {
+				assert.Equal(t, test.expectedSeed, resp.Body.Electrum.Seed)
+			}
+			assert.Equal(t, test.expectedWarning, resp.Body.Warning)
+		})
+	}
+}
+
+func TestElectrumNew(t *testing.T) {
+	tests := map[string]struct {
+		seedType           string
+		expectedCode       int
+		expectedType       string
+		expectedDerivation string
+	}{
+		"segwit by default": {expectedCode: 200, expectedType: electrum.SeedSegwit, expectedDerivation: "m/0'/0/"},
+		"standard":          {seedType: electrum.SeedStandard, expectedCode: 200, expectedType: electrum.SeedStandard, expectedDerivation: "m/0/"},
+		"2fa":               {seedType: electrum.Seed2FA, expectedCode: 200, expectedType: electrum.Seed2FA},
+		"old":               {seedType: electrum.SeedOld, expectedCode: 400},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(Request{Op: OpElectrumNew, SeedType: test.seedType})
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if resp.StatusCode != 200 {
+				return
+			}
+			assert.Equal(t, 12, len(strings.Fields(resp.Body.Mnemonic)))
+			assert.Equal(t, test.expectedType, resp.Body.Electrum.Type)
+			assert.Equal(t, test.expectedDerivation, resp.Body.Electrum.Derivation)
+			assert.False(t, resp.Body.Electrum.BIP39)
+			assert.Len(t, resp.Body.Electrum.Seed, 128)
+			assert.Empty(t, resp.Body.Warning)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pnowosie/complete-mnemonic/bip39"
+)
+
+// This file is a copy of ../wallet/entropy.go but for the bip39 import,
+// keep both in sync. The functions are deployed as separate modules, so they
+// cannot import a shared package.
+
+// diceBits is the information of a single die roll
+var diceBits = math.Log2(6)
+
+// EntropyBody reports how much entropy the input supplied and how much of it
+// the mnemonic uses.
+type EntropyBody struct {
+	Format       string `json:"format"`
+	Symbols      int    `json:"symbols"`
+	SuppliedBits int    `json:"suppliedBits"`
+	UsedBits     int    `json:"usedBits"`
+}
+
+// parseEntropy converts the user's entropy into mnemonic entropy. Hex and binary
+// inputs are the entropy, so their size sets the mnemonic length. Dice rolls and
+// coin flips are converted into bits until there is enough for the length, the
+// remaining symbols are ignored.
+//
+// Dice rolls are converted without bias: 1, 2, 3 and 4 give two bits 00, 01, 10
+// and 11, 5 and 6 give one bit 0 and 1. That's 1.67 bits on average of the 2.58
+// bits a roll supplies, 85 rolls are practically always enough for 12 words and
+// 165 rolls for 24 words. Coin flips give one bit each, heads is 1 and tails is 0.
+func parseEntropy(format, input string, length int) ([]byte, *EntropyBody, error) {
+	input = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '_' || r == ',' || r == '\n' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(input))
+	body := &EntropyBody{Format: format, Symbols: len(input)}
+
+	var bits strings.Builder
+	switch format {
+	case EntropyHex:
+		input = strings.TrimPrefix(input, "0x")
+		entropy, err := hex.DecodeString(input)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid hex entropy: %w", err)
+		}
+		body.Symbols, body.SuppliedBits, body.UsedBits = len(input), len(entropy)*8, len(entropy)*8
+		return entropy, body, nil
+	case EntropyBinary:
+		for i, c := range input {
+			if c != '0' && c != '1' {
+				return nil, nil, fmt.Errorf("invalid binary digit '%c' at position %d", c, i)
+			}
+		}
+		body.SuppliedBits, body.UsedBits = len(input), len(input)
+		if len(input)%8 != 0 {
+			return nil, nil, bip39.ErrEntropyLengthInvalid
+		}
+		return bitsToBytes(input), body, nil
+	case EntropyCoins:
+		for i, c := range input {
+			switch c {
+			case 'h', '1':
+				bits.WriteByte('1')
+			case 't', '0':
+				bits.WriteByte('0')
+			default:
+				return nil, nil, fmt.Errorf("invalid coin flip '%c' at position %d, expected h or t", c, i)
+			}
+		}
+		body.SuppliedBits = len(input)
+	case EntropyDice:
+		for i, c := range input {
+			switch {
+			case c >= '1' && c <= '4':
+				fmt.Fprintf(&bits, "%02b", c-'1')
+			case c == '5' || c == '6':
+				fmt.Fprintf(&bits, "%b", c-'5')
+			default:
+				return nil, nil, fmt.Errorf("invalid die roll '%c' at position %d, expected 1 to 6", c, i)
+			}
+		}
+		body.SuppliedBits = int(float64(len(input)) * diceBits)
+	default:
+		return nil, nil, fmt.Errorf("invalid entropy format '%s', accepted values: %s, %s, %s, %s",
+			format, EntropyHex, EntropyBinary, EntropyDice, EntropyCoins)
+	}
+
+	if err := hasCorrectWordsLength(length); err != nil {
+		return nil, nil, err
+	}
+	needed := length*11 - length/3
+	if bits.Len() < needed {
+		return nil, nil, fmt.Errorf("%d %s give %d bits, %d bits are needed for %d words",
+			len(input), format, bits.Len(), needed, length)
+	}
+	body.UsedBits = needed
+	return bitsToBytes(bits.String()[:needed]), body, nil
+}
+
+// bitsToBytes packs the string of binary digits, its length is multiple of 8.
+func bitsToBytes(bits string) []byte {
+	b := make([]byte, len(bits)/8)
+	for i := range b {
+		for _, c := range bits[i*8 : i*8+8] {
+			b[i] = b[i]<<1 | byte(c-'0')
+		}
+	}
+	return b
+}
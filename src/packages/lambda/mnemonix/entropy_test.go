@@ -0,0 +1,92 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	abandonAbout = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	zooWrong     = "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong"
+	legalYellow  = "legal winner thank year wave sausage worth useful legal winner thank yellow"
+)
+
+func TestMnemonicFromEntropy(t *testing.T) {
+	tests := map[string]struct {
+		req              *Request
+		expectedCode     int
+		expectedMnemonic string
+		expectedEntropy  *EntropyBody
+	}{
+		"hex by default": {
+			req:              &Request{Entropy: strings.Repeat("7f", 16)},
+			expectedCode:     200,
+			expectedMnemonic: legalYellow,
+			expectedEntropy:  &EntropyBody{Format: EntropyHex, Symbols: 32, SuppliedBits: 128, UsedBits: 128},
+		},
+		"hex sets the length": {
+			req:              &Request{Entropy: "0x" + strings.Repeat("00", 32), Length: 12},
+			expectedCode:     200,
+			expectedMnemonic: strings.Repeat("abandon ", 23) + "art",
+			expectedEntropy:  &EntropyBody{Format: EntropyHex, Symbols: 64, SuppliedBits: 256, UsedBits: 256},
+		},
+		"binary": {
+			req:              &Request{Entropy: strings.Repeat("0111_1111_", 16), EntropyFormat: EntropyBinary},
+			expectedCode:     200,
+			expectedMnemonic: legalYellow,
+			expectedEntropy:  &EntropyBody{Format: EntropyBinary, Symbols: 128, SuppliedBits: 128, UsedBits: 128},
+		},
+		"coins": {
+			req:              &Request{Entropy: strings.Repeat("H", 130), EntropyFormat: EntropyCoins},
+			expectedCode:     200,
+			expectedMnemonic: zooWrong,
+			expectedEntropy:  &EntropyBody{Format: EntropyCoins, Symbols: 130, SuppliedBits: 130, UsedBits: 128},
+		},
+		"dice giving two bits": {
+			req:              &Request{Entropy: strings.Repeat("1", 64), EntropyFormat: EntropyDice},
+			expectedCode:     200,
+			expectedMnemonic: abandonAbout,
+			expectedEntropy:  &EntropyBody{Format: EntropyDice, Symbols: 64, SuppliedBits: 165, UsedBits: 128},
+		},
+		"dice giving one bit": {
+			req:              &Request{Entropy: strings.Repeat("6", 128), EntropyFormat: EntropyDice},
+			expectedCode:     200,
+			expectedMnemonic: zooWrong,
+			expectedEntropy:  &EntropyBody{Format: EntropyDice, Symbols: 128, SuppliedBits: 330, UsedBits: 128},
+		},
+		"dice of both": {
+			req:              &Request{Entropy: strings.Repeat("2,4,4,6,6,", 16), EntropyFormat: EntropyDice},
+			expectedCode:     200,
+			expectedMnemonic: legalYellow,
+			expectedEntropy:  &EntropyBody{Format: EntropyDice, Symbols: 80, SuppliedBits: 206, UsedBits: 128},
+		},
+		"not enough rolls": {
+			req:          &Request{Entropy: strings.Repeat("1", 64), EntropyFormat: EntropyDice, Length: 24},
+			expectedCode: 400,
+		},
+		"invalid die": {
+			req:          &Request{Entropy: "1234567", EntropyFormat: EntropyDice},
+			expectedCode: 400,
+		},
+		"invalid hex length": {
+			req:          &Request{Entropy: strings.Repeat("00", 15)},
+			expectedCode: 400,
+		},
+		"invalid format": {
+			req:          &Request{Entropy: "00", EntropyFormat: "cards"},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			assert.Equal(t, test.expectedMnemonic, resp.Body.Mnemonic)
+			assert.Equal(t, test.expectedEntropy, resp.Body.Entropy)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/pnowosie/complete-mnemonic/bip39"
+)
+
+var entropyByteLengths = []int{16, 20, 24, 28, 32}
+
+func FuzzPossibleLastBytes(f *testing.F) {
+	f.Add(uint8(0), []byte{0xab}, 4)
+	f.Add(uint8(2), []byte{0x00}, 1)
+	f.Add(uint8(4), []byte{0xff}, 300)
+	f.Add(uint8(1), []byte{0x5a}, 7)
+
+	f.Fuzz(func(t *testing.T, lengthIdx uint8, seed []byte, length int) {
+		if length < 1 || length > 1<<11 {
+			t.Skip()
+		}
+		entropyLen := entropyByteLengths[int(lengthIdx)%len(entropyByteLengths)]
+		entropy := make([]byte, entropyLen)
+		copy(entropy, seed)
+
+		var (
+			lastByte        = entropy[entropyLen-1]
+			lastWordBitsLen = 11 - entropyLen*8/32
+			mask            = byte(0xff << lastWordBitsLen)
+			expectedLen     = int(math.Min(float64(length), float64(int(1)<<lastWordBitsLen)))
+		)
+
+		lastBytes := PossibleLastBytes(entropyLen, lastByte, length)
+		if len(lastBytes) != expectedLen {
+			t.Fatalf("got %d bytes, expected %d", len(lastBytes), expectedLen)
+		}
+
+		lastWords := map[string]bool{}
+		for _, b := range lastBytes {
+			if b&mask != lastByte&mask {
+				t.Fatalf("byte %08b does not preserve mask %08b of %08b", b, mask, lastByte)
+			}
+			entropy[entropyLen-1] = b
+			mn, err := bip39.NewMnemonic(entropy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bip39.IsMnemonicValid(mn) {
+				t.Fatalf("mnemonic '%s' is invalid", mn)
+			}
+			words := strings.Fields(mn)
+			last := words[len(words)-1]
+			if lastWords[last] {
+				t.Fatalf("last word '%s' repeated for byte %08b", last, b)
+			}
+			lastWords[last] = true
+		}
+	})
+}
+
+func FuzzRepeat(f *testing.F) {
+	f.Add("abandon", 12)
+	f.Add("air_age_act", 24)
+	f.Add("air age act air age act air age act air age act fox", 12)
+	f.Add("fox", 13)
+	f.Add("", 15)
+
+	f.Fuzz(func(t *testing.T, phrase string, length int) {
+		mn, err := Repeat(phrase, length)
+		if err != nil {
+			return
+		}
+
+		phraseWords, _ := toWordList(phrase)
+		words := strings.Fields(mn)
+		if len(words) < 12 || len(words)%3 != 0 {
+			t.Fatalf("repeated '%s' into %d words", phrase, len(words))
+		}
+		for i, word := range words {
+			if word != phraseWords[i%len(phraseWords)] {
+				t.Fatalf("word '%s' at position %d does not repeat the phrase '%s'", word, i, phrase)
+			}
+		}
+		if _, err := bip39.EntropyFromMnemonic(mn); err != nil && err != bip39.ErrChecksumIncorrect {
+			t.Fatalf("repeated '%s' is not a mnemonic: %v", phrase, err)
+		}
+	})
+}
+
+func FuzzToWordList(f *testing.F) {
+	f.Add("abandon_about")
+	f.Add("  zoo\tzoo\n")
+	f.Add("not-a-word")
+	f.Add("\xff\xfe")
+
+	f.Fuzz(func(t *testing.T, phrase string) {
+		words, err := toWordList(phrase)
+		if err != nil {
+			if len(words) != 0 {
+				t.Fatalf("words returned along with error: %v", err)
+			}
+			return
+		}
+		if len(words) == 0 {
+			t.Fatal("no words and no error")
+		}
+		for _, word := range words {
+			if _, ok := bip39.GetWordIndex(word); !ok {
+				t.Fatalf("word '%s' is not in WordList", word)
+			}
+		}
+	})
+}
@@ -2,10 +2,16 @@ module github.com/pnowosie/complete-mnemonic
 
 go 1.20
 
+require (
+	github.com/makiuchi-d/gozxing v0.1.1
+	github.com/stretchr/testify v1.8.2
+	golang.org/x/crypto v0.8.0
+	golang.org/x/text v0.14.0
+)
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.8.2 // indirect
-	golang.org/x/crypto v0.8.0 // indirect
+	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -9,24 +9,67 @@ import (
 
 func Main(in Request) (*Response, error) {
 	in.AssumeDefaults()
+	switch in.Op {
+	case OpSlip39Combine:
+		return combineSlip39(in), nil
+	case OpCodex32Combine:
+		return combineCodex32(in), nil
+	case OpXorCombine:
+		return combineXor(in), nil
+	case OpElectrumNew:
+		return newElectrumSeed(in), nil
+	case OpElectrumCheck:
+		return checkElectrumSeed(in), nil
+	case OpSeedQRDecode:
+		return decodeSeedQR(in), nil
+	}
 
-	mn, err := Repeat(in.Phrase, in.Length)
-	if err != nil {
-		return &Response{
-			StatusCode: http.StatusBadRequest,
-			Body:       ResponseBody{Error: err.Error()},
-		}, nil
+	var (
+		en       []byte
+		supplied *EntropyBody
+	)
+	if in.Entropy != "" {
+		var err error
+		if en, supplied, err = parseEntropy(in.EntropyFormat, in.Entropy, in.Length); err != nil {
+			return errorResponse(http.StatusBadRequest, err), nil
+		}
+	} else if in.Mnemonic != "" {
+		var err error
+		if en, err = bip39.EntropyFromMnemonic(strings.ReplaceAll(in.Mnemonic, "_", " ")); err != nil {
+			return errorResponse(http.StatusBadRequest, err), nil
+		}
+	} else {
+		mn, err := Repeat(in.Phrase, in.Length)
+		if err != nil {
+			return &Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       ResponseBody{Error: err.Error()},
+			}, nil
+		}
+		en, _ = bip39.EntropyFromMnemonic(mn)
 	}
 
-	en, _ := bip39.EntropyFromMnemonic(mn)
-	mn, _ = bip39.NewMnemonic(en)
+	mn, err := bip39.NewMnemonic(en)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err), nil
+	}
+	switch in.Op {
+	case OpSlip39Split:
+		return splitSlip39(in, mn, en), nil
+	case OpCodex32Split:
+		return splitCodex32(in, mn, en), nil
+	case OpXorSplit:
+		return splitXor(in, mn), nil
+	case OpSeedQR:
+		return encodeSeedQR(in, mn), nil
+	}
 	ends := possibleLastWords(en, in.EndWords)
 
 	words := strings.Fields(mn)
 	return &Response{
 		StatusCode: http.StatusOK,
 		Body: ResponseBody{
-			Mnemonic: mn, Ends: strings.Join(ends, " "), Length: len(words)},
+			Mnemonic: mn, Ends: strings.Join(ends, " "), Length: len(words), Entropy: supplied},
 	}, nil
 }
 
@@ -74,7 +74,7 @@ func hasCorrectWordsLength(length int) error {
 }
 
 func toWordList(phrase string) ([]string, error) {
-	phrase = strings.ReplaceAll(phrase, "_", " ")
+	phrase = bip39.Normalize(strings.ReplaceAll(phrase, "_", " "))
 	words := strings.Fields(phrase)
 	if len(words) == 0 {
 		return []string{}, fmt.Errorf("no words found in '%s'", phrase)
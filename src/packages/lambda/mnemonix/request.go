@@ -1,8 +1,33 @@
 package main
 
+import (
+	"github.com/pnowosie/complete-mnemonic/bip39"
+	"github.com/pnowosie/complete-mnemonic/electrum"
+)
+
 const (
 	DefaultPhraseLength    = 12
 	DefaultMaxCorrectWords = 0
+	DefaultGroupThreshold  = 1
+	DefaultGroups          = "1of1"
+
+	OpSlip39Split    = "slip39-split"
+	OpSlip39Combine  = "slip39-combine"
+	OpCodex32Split   = "codex32-split"
+	OpCodex32Combine = "codex32-combine"
+	OpXorSplit       = "xor-split"
+	OpXorCombine     = "xor-combine"
+	OpElectrumNew    = "electrum-new"
+	OpElectrumCheck  = "electrum-check"
+	OpSeedQR         = "seedqr"
+	OpSeedQRDecode   = "seedqr-decode"
+
+	EntropyHex    = "hex"
+	EntropyBinary = "binary"
+	EntropyDice   = "dice"
+	EntropyCoins  = "coins"
+
+	AmbiguousSeedWarning = "words are valid both as BIP-39 mnemonic and Electrum seed, wallets derive different accounts from each"
 )
 
 // Request is the function's request struct
@@ -10,6 +35,21 @@ type Request struct {
 	Phrase   string `json:"phrase"`
 	Length   int    `json:"length,string,omitempty"`
 	EndWords int    `json:"endWords,string,omitempty"`
+
+	Op             string `json:"op,omitempty"`
+	GroupThreshold int    `json:"groupThreshold,string,omitempty"`
+	Groups         string `json:"groups,omitempty"`
+	Passphrase     string `json:"passphrase,omitempty"`
+	Shares         string `json:"shares,omitempty"`
+	Threshold      int    `json:"threshold,string,omitempty"`
+	Count          int    `json:"count,string,omitempty"`
+	Identifier     string `json:"identifier,omitempty"`
+	Mnemonic       string `json:"mnemonic,omitempty"`
+	SeedType       string `json:"seedType,omitempty"`
+	Entropy        string `json:"entropy,omitempty"`
+	EntropyFormat  string `json:"entropyFormat,omitempty"`
+	Compact        bool   `json:"compact,string,omitempty"`
+	Payload        string `json:"payload,omitempty"`
 }
 
 // Response is the function's response struct
@@ -20,10 +60,26 @@ type Response struct {
 }
 
 type ResponseBody struct {
-	Mnemonic string `json:"mnemonic"`
-	Length   int    `json:"length"`
-	Ends     string `json:"ends,omitempty"`
-	Error    string `json:"error,omitempty"`
+	Mnemonic string        `json:"mnemonic"`
+	Length   int           `json:"length"`
+	Ends     string        `json:"ends,omitempty"`
+	Shares   [][]string    `json:"shares,omitempty"`
+	Codex32  []string      `json:"codex32,omitempty"`
+	Parts    []string      `json:"parts,omitempty"`
+	Electrum *ElectrumBody `json:"electrum,omitempty"`
+	Entropy  *EntropyBody  `json:"entropy,omitempty"`
+	SeedQR   *SeedQRBody   `json:"seedqr,omitempty"`
+	Warning  string        `json:"warning,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// ElectrumBody describes the Electrum seed and what it derives
+type ElectrumBody struct {
+	Type       string `json:"type,omitempty"`
+	BIP39      bool   `json:"bip39"`
+	Seed       string `json:"seed,omitempty"`
+	MasterKey  string `json:"masterKey,omitempty"`
+	Derivation string `json:"derivation,omitempty"`
 }
 
 func (req *Request) AssumeDefaults() {
@@ -33,4 +89,29 @@ func (req *Request) AssumeDefaults() {
 	if req.EndWords == 0 {
 		req.EndWords = DefaultMaxCorrectWords
 	}
+	if req.Op == OpSlip39Split && req.GroupThreshold == 0 {
+		req.GroupThreshold = DefaultGroupThreshold
+	}
+	if req.Op == OpSlip39Split && req.Groups == "" {
+		req.Groups = DefaultGroups
+	}
+	if req.Op == OpCodex32Split && req.Count == 0 {
+		req.Count = req.Threshold
+	}
+	if req.Entropy != "" && req.EntropyFormat == "" {
+		req.EntropyFormat = EntropyHex
+	}
+	if req.Op == OpElectrumNew && req.SeedType == "" {
+		req.SeedType = electrum.SeedSegwit
+	}
+	if req.Op == OpXorSplit && req.Count == 0 {
+		req.Count = bip39.MinXorParts
+	}
+}
+
+func errorResponse(statusCode int, err error) *Response {
+	return &Response{
+		StatusCode: statusCode,
+		Body:       ResponseBody{Error: err.Error()},
+	}
 }
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/pnowosie/complete-mnemonic/seedqr"
+)
+
+// qrScale is the module size of the rendered QR codes in pixels.
+const qrScale = 8
+
+// SeedQRBody is the SeedQR payload with its QR code as base64 PNG and SVG.
+type SeedQRBody struct {
+	Format  string `json:"format"`
+	Payload string `json:"payload"`
+	Version int    `json:"version"`
+	PNG     string `json:"png,omitempty"`
+	SVG     string `json:"svg,omitempty"`
+}
+
+// encodeSeedQR encodes the mnemonic as SeedQR or CompactSeedQR and renders it.
+func encodeSeedQR(in Request, mnemonic string) *Response {
+	var (
+		body    = &SeedQRBody{Format: seedqr.Standard}
+		payload []byte
+	)
+	if in.Compact {
+		entropy, err := seedqr.EncodeCompact(mnemonic)
+		if err != nil {
+			return errorResponse(http.StatusBadRequest, err)
+		}
+		body.Format, body.Payload, payload = seedqr.Compact, hex.EncodeToString(entropy), entropy
+	} else {
+		digits, err := seedqr.Encode(mnemonic)
+		if err != nil {
+			return errorResponse(http.StatusBadRequest, err)
+		}
+		body.Payload, payload = digits, []byte(digits)
+	}
+
+	q, err := seedqr.QR(body.Format, payload)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, err)
+	}
+	png, err := q.PNG(qrScale)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, err)
+	}
+	body.Version = q.Version
+	body.PNG = base64.StdEncoding.EncodeToString(png)
+	body.SVG = q.SVG(qrScale)
+
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Mnemonic: mnemonic,
+			Length:   len(strings.Fields(mnemonic)),
+			SeedQR:   body,
+		},
+	}
+}
+
+// decodeSeedQR recovers the mnemonic of SeedQR digits or CompactSeedQR bytes in
+// hex, told apart by the length.
+func decodeSeedQR(in Request) *Response {
+	payload := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(in.Payload)), "0x")
+	body := &SeedQRBody{Format: seedqr.Standard, Payload: payload}
+
+	var (
+		mnemonic string
+		err      error
+	)
+	if len(payload) == 48 || len(payload) == 96 {
+		mnemonic, err = seedqr.Decode(payload)
+	} else {
+		var entropy []byte
+		if entropy, err = hex.DecodeString(payload); err == nil {
+			body.Format = seedqr.Compact
+			mnemonic, err = seedqr.DecodeCompact(entropy)
+		}
+	}
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Mnemonic: mnemonic,
+			Length:   len(strings.Fields(mnemonic)),
+			SeedQR:   body,
+		},
+	}
+}
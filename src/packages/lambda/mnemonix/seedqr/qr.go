@@ -0,0 +1,216 @@
+package seedqr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/common/reedsolomon"
+	"github.com/makiuchi-d/gozxing/qrcode/decoder"
+	"github.com/makiuchi-d/gozxing/qrcode/encoder"
+)
+
+const (
+	quietZone = 4
+	numMasks  = 8
+)
+
+// ErrPayloadTooLong is returned when the payload doesn't fit a single block QR code.
+var ErrPayloadTooLong = errors.New("SeedQR payload too long")
+
+// Symbol is the QR code of a SeedQR payload. The payload is a single segment,
+// numeric for SeedQR and byte for CompactSeedQR as the spec requires, scanners
+// of SeedSigner read the first segment only.
+type Symbol struct {
+	Version int
+	modules *encoder.ByteMatrix
+}
+
+// QR encodes the payload of the format with low error correction as SeedSigner
+// makes them, 12 words fit 25x25 SeedQR and 21x21 CompactSeedQR.
+func QR(format string, payload []byte) (*Symbol, error) {
+	var (
+		mode *decoder.Mode
+		data = gozxing.NewEmptyBitArray()
+	)
+	switch format {
+	case Standard:
+		mode = decoder.Mode_NUMERIC
+		if err := appendNumeric(data, payload); err != nil {
+			return nil, err
+		}
+	case Compact:
+		mode = decoder.Mode_BYTE
+		for _, b := range payload {
+			_ = data.AppendBits(int(b), 8)
+		}
+	default:
+		return nil, fmt.Errorf("invalid SeedQR format '%s'", format)
+	}
+
+	// versions 1-5 of low error correction have a single block, no interleaving
+	for v := 1; v <= 5; v++ {
+		version, _ := decoder.Version_GetVersionForNumber(v)
+		ecBlocks := version.GetECBlocksForLevel(decoder.ErrorCorrectionLevel_L)
+		numDataBytes := version.GetTotalCodewords() - ecBlocks.GetTotalECCodewords()
+
+		bits := gozxing.NewEmptyBitArray()
+		_ = bits.AppendBits(mode.GetBits(), 4)
+		_ = bits.AppendBits(len(payload), mode.GetCharacterCountBits(version))
+		bits.AppendBitArray(data)
+		if bits.GetSize() > numDataBytes*8 {
+			continue
+		}
+		codewords, err := codewords(bits, numDataBytes, ecBlocks.GetTotalECCodewords())
+		if err != nil {
+			return nil, err
+		}
+		modules, err := bestMask(codewords, version)
+		if err != nil {
+			return nil, err
+		}
+		return &Symbol{Version: v, modules: modules}, nil
+	}
+	return nil, ErrPayloadTooLong
+}
+
+// appendNumeric encodes digits in groups of 3 into 10 bits, the rest into 7 or 4.
+func appendNumeric(bits *gozxing.BitArray, digits []byte) error {
+	for i := 0; i < len(digits); i += 3 {
+		end := i + 3
+		if end > len(digits) {
+			end = len(digits)
+		}
+		group := digits[i:end]
+		value := 0
+		for _, d := range group {
+			if d < '0' || d > '9' {
+				return fmt.Errorf("%w: '%c' is not a digit", ErrInvalidPayload, d)
+			}
+			value = value*10 + int(d-'0')
+		}
+		_ = bits.AppendBits(value, 3*len(group)+1)
+	}
+	return nil
+}
+
+// codewords terminates and pads the bits to the data codewords and appends
+// their Reed-Solomon error correction.
+func codewords(bits *gozxing.BitArray, numDataBytes, numECBytes int) (*gozxing.BitArray, error) {
+	capacity := numDataBytes * 8
+	for i := 0; i < 4 && bits.GetSize() < capacity; i++ {
+		bits.AppendBit(false)
+	}
+	for bits.GetSize()%8 != 0 {
+		bits.AppendBit(false)
+	}
+	for pad := 0xec; bits.GetSize() < capacity; pad ^= 0xec ^ 0x11 {
+		_ = bits.AppendBits(pad, 8)
+	}
+
+	data := make([]byte, numDataBytes)
+	bits.ToBytes(0, data, 0, numDataBytes)
+	block := make([]int, numDataBytes+numECBytes)
+	for i, b := range data {
+		block[i] = int(b)
+	}
+	if err := reedsolomon.NewReedSolomonEncoder(reedsolomon.GenericGF_QR_CODE_FIELD_256).Encode(block, numECBytes); err != nil {
+		return nil, err
+	}
+	final := gozxing.NewEmptyBitArray()
+	for _, b := range block {
+		_ = final.AppendBits(b, 8)
+	}
+	return final, nil
+}
+
+// bestMask places the codewords with the mask pattern of the lowest penalty.
+func bestMask(codewords *gozxing.BitArray, version *decoder.Version) (*encoder.ByteMatrix, error) {
+	dimension := version.GetDimensionForVersion()
+	best, minPenalty := -1, math.MaxInt
+	for mask := 0; mask < numMasks; mask++ {
+		matrix := encoder.NewByteMatrix(dimension, dimension)
+		if err := encoder.MatrixUtil_buildMatrix(codewords, decoder.ErrorCorrectionLevel_L, version, mask, matrix); err != nil {
+			return nil, err
+		}
+		penalty := encoder.MaskUtil_applyMaskPenaltyRule1(matrix) + encoder.MaskUtil_applyMaskPenaltyRule2(matrix) +
+			encoder.MaskUtil_applyMaskPenaltyRule3(matrix) + encoder.MaskUtil_applyMaskPenaltyRule4(matrix)
+		if penalty < minPenalty {
+			best, minPenalty = mask, penalty
+		}
+	}
+	matrix := encoder.NewByteMatrix(dimension, dimension)
+	return matrix, encoder.MatrixUtil_buildMatrix(codewords, decoder.ErrorCorrectionLevel_L, version, best, matrix)
+}
+
+// Bitmap returns the dark modules, bitmap[y][x], with the quiet zone around.
+func (s *Symbol) Bitmap() [][]bool {
+	size := s.modules.GetWidth() + 2*quietZone
+	bitmap := make([][]bool, size)
+	for y := range bitmap {
+		bitmap[y] = make([]bool, size)
+	}
+	for y := 0; y < s.modules.GetHeight(); y++ {
+		for x := 0; x < s.modules.GetWidth(); x++ {
+			bitmap[y+quietZone][x+quietZone] = s.modules.Get(x, y) == 1
+		}
+	}
+	return bitmap
+}
+
+// PNG renders the QR code, scale is the module size in pixels.
+func (s *Symbol) PNG(scale int) ([]byte, error) {
+	bitmap := s.Bitmap()
+	img := image.NewPaletted(image.Rect(0, 0, len(bitmap)*scale, len(bitmap)*scale), color.Palette{color.White, color.Black})
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			for i := 0; i < scale*scale; i++ {
+				img.SetColorIndex(x*scale+i%scale, y*scale+i/scale, 1)
+			}
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// SVG renders the QR code, scale is the module size in pixels.
+func (s *Symbol) SVG(scale int) string {
+	return svgPath(s.Bitmap(), scale)
+}
+
+// svgPath draws the bitmap as a single path of the dark module runs of each row.
+func svgPath(bitmap [][]bool, scale int) string {
+	size := len(bitmap)
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		size*scale, size*scale, size, size)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, size, size)
+	for y, row := range bitmap {
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+			run := 0
+			for x+run < len(row) && row[x+run] {
+				run++
+			}
+			fmt.Fprintf(&b, "M%d %dh%dv1h-%dz", x, y, run, run)
+			x += run
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	return b.String()
+}
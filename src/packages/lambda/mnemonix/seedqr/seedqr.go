@@ -0,0 +1,96 @@
+// Package seedqr encodes BIP-39 mnemonics as SeedQR and CompactSeedQR of
+// SeedSigner.
+//
+// The SeedQR spec can be found at
+// https://github.com/SeedSigner/seedsigner/blob/dev/docs/seed_qr/README.md
+package seedqr
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pnowosie/complete-mnemonic/bip39"
+)
+
+// Payload formats.
+const (
+	Standard = "standard"
+	Compact  = "compact"
+)
+
+const digitsPerWord = 4
+
+var (
+	// ErrUnsupportedLength is returned for mnemonics other than 12 or 24 words,
+	// SeedSigner doesn't scan them.
+	ErrUnsupportedLength = errors.New("SeedQR supports 12 or 24 word mnemonics only")
+
+	// ErrInvalidPayload is returned when the payload is not a SeedQR.
+	ErrInvalidPayload = errors.New("Invalid SeedQR payload")
+)
+
+// Encode returns the SeedQR digits, 4-digit BIP-39 word index of each word.
+func Encode(mnemonic string) (string, error) {
+	words := strings.Fields(mnemonic)
+	if err := checkLength(len(words)); err != nil {
+		return "", err
+	}
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return "", bip39.ErrInvalidMnemonic
+	}
+	var digits strings.Builder
+	for _, word := range words {
+		index, _ := bip39.GetWordIndex(word)
+		fmt.Fprintf(&digits, "%0*d", digitsPerWord, index)
+	}
+	return digits.String(), nil
+}
+
+// EncodeCompact returns the CompactSeedQR bytes, the entropy of the mnemonic.
+func EncodeCompact(mnemonic string) ([]byte, error) {
+	if err := checkLength(len(strings.Fields(mnemonic))); err != nil {
+		return nil, err
+	}
+	return bip39.EntropyFromMnemonic(mnemonic)
+}
+
+// Decode returns the mnemonic of the SeedQR digits.
+func Decode(digits string) (string, error) {
+	if len(digits)%digitsPerWord != 0 {
+		return "", fmt.Errorf("%w: expected %d digits per word", ErrInvalidPayload, digitsPerWord)
+	}
+	if err := checkLength(len(digits) / digitsPerWord); err != nil {
+		return "", err
+	}
+	wordList := bip39.GetWordList()
+	words := make([]string, 0, len(digits)/digitsPerWord)
+	for i := 0; i < len(digits); i += digitsPerWord {
+		index, err := strconv.ParseUint(digits[i:i+digitsPerWord], 10, 16)
+		if err != nil || int(index) >= len(wordList) {
+			return "", fmt.Errorf("%w: word index '%s' at position %d", ErrInvalidPayload, digits[i:i+digitsPerWord], i/digitsPerWord)
+		}
+		words = append(words, wordList[index])
+	}
+	mnemonic := strings.Join(words, " ")
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return "", bip39.ErrChecksumIncorrect
+	}
+	return mnemonic, nil
+}
+
+// DecodeCompact returns the mnemonic of the CompactSeedQR bytes.
+func DecodeCompact(entropy []byte) (string, error) {
+	if len(entropy) != 16 && len(entropy) != 32 {
+		return "", ErrUnsupportedLength
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+func checkLength(words int) error {
+	if words != 12 && words != 24 {
+		return ErrUnsupportedLength
+	}
+	return nil
+}
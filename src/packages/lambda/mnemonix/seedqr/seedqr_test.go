@@ -0,0 +1,175 @@
+package seedqr
+
+import (
+	"bytes"
+	"encoding/hex"
+	"image/png"
+	"testing"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/qrcode"
+	"github.com/pnowosie/complete-mnemonic/bip39"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vectors are examples of the SeedQR spec, compact payloads are their entropy.
+var vectors = []struct {
+	mnemonic       string
+	digits         string
+	compact        string
+	version        int
+	compactVersion int
+}{
+	{
+		mnemonic:       "forum undo fragile fade shy sign arrest garment culture tube off merit",
+		digits:         "073318950739065415961602009907670428187212261116",
+		compact:        "5bbd9d71a8ec7990831aff359d426545",
+		version:        2,
+		compactVersion: 1,
+	},
+	{
+		mnemonic:       "attack pizza motion avocado network gather crop fresh patrol unusual wild holiday candy pony ranch winter theme error hybrid van cereal salon goddess expire",
+		digits:         "011513251154012711900771041507421289190620080870026613431420201617920614089619290300152408010643",
+		compact:        "0e74b64107f94cc0ccfae6a13dcbec3662154fec67e0e00999c07892597d190a",
+		version:        3,
+		compactVersion: 2,
+	},
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range vectors {
+		digits, err := Encode(v.mnemonic)
+		require.NoError(t, err)
+		assert.Equal(t, v.digits, digits)
+
+		entropy, err := EncodeCompact(v.mnemonic)
+		require.NoError(t, err)
+		assert.Equal(t, v.compact, hex.EncodeToString(entropy))
+
+		mnemonic, err := Decode(v.digits)
+		require.NoError(t, err)
+		assert.Equal(t, v.mnemonic, mnemonic)
+
+		mnemonic, err = DecodeCompact(entropy)
+		require.NoError(t, err)
+		assert.Equal(t, v.mnemonic, mnemonic)
+	}
+}
+
+func TestQRVersion(t *testing.T) {
+	for _, v := range vectors {
+		q, err := QR(Standard, []byte(v.digits))
+		require.NoError(t, err)
+		assert.Equal(t, v.version, q.Version)
+
+		entropy, _ := hex.DecodeString(v.compact)
+		q, err = QR(Compact, entropy)
+		require.NoError(t, err)
+		assert.Equal(t, v.compactVersion, q.Version)
+	}
+}
+
+func TestPNGScansAsSingleSegment(t *testing.T) {
+	type symbol struct {
+		format  string
+		payload []byte
+	}
+	symbols := map[string]symbol{
+		// a mode optimizing encoder splits these into numeric and byte segments
+		"compact starting with digits": {Compact, mustDecodeHex("30313233343536373839" + "3031" + "ff80" + "4142")},
+		"compact of digits only":       {Compact, []byte("0123456789012345")},
+	}
+	for _, v := range vectors {
+		symbols[v.digits[:8]] = symbol{Standard, []byte(v.digits)}
+		symbols[v.compact[:8]] = symbol{Compact, mustDecodeHex(v.compact)}
+	}
+
+	for name, s := range symbols {
+		t.Run(name, func(t *testing.T) {
+			q, err := QR(s.format, s.payload)
+			require.NoError(t, err)
+			img, err := q.PNG(4)
+			require.NoError(t, err)
+
+			mode, count, segments, text := scan(t, img)
+			assert.Equal(t, len(s.payload), count, "first segment holds the whole payload")
+			if s.format == Compact {
+				assert.Equal(t, 4, mode, "byte mode")
+				assert.Equal(t, [][]byte{s.payload}, segments)
+			} else {
+				assert.Equal(t, 1, mode, "numeric mode")
+				assert.Nil(t, segments)
+				assert.Equal(t, string(s.payload), text)
+			}
+		})
+	}
+}
+
+func mustDecodeHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestInvalid(t *testing.T) {
+	tests := map[string]struct {
+		decode      func() (string, error)
+		expectedErr error
+	}{
+		"15 words": {
+			decode: func() (string, error) {
+				return Encode("legal winner thank year wave sausage worth useful legal winner thank year wave sausage wise")
+			},
+			expectedErr: ErrUnsupportedLength,
+		},
+		"word index out of range": {
+			decode:      func() (string, error) { return Decode("204807391895065415961602009907670428187212261116") },
+			expectedErr: ErrInvalidPayload,
+		},
+		"not digits": {
+			decode:      func() (string, error) { return Decode("07331895073906541596160200990767042818721226111x") },
+			expectedErr: ErrInvalidPayload,
+		},
+		"wrong checksum": {
+			decode:      func() (string, error) { return Decode("073318950739065415961602009907670428187212261117") },
+			expectedErr: bip39.ErrChecksumIncorrect,
+		},
+		"20 bytes": {
+			decode:      func() (string, error) { return DecodeCompact(make([]byte, 20)) },
+			expectedErr: ErrUnsupportedLength,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := test.decode()
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+// scan decodes the QR code of the PNG image as a scanner would. It returns the
+// mode and character count of the first segment, the byte segments and the text.
+func scan(t *testing.T, img []byte) (mode, count int, segments [][]byte, text string) {
+	t.Helper()
+	decoded, err := png.Decode(bytes.NewReader(img))
+	require.NoError(t, err)
+	bmp, err := gozxing.NewBinaryBitmapFromImage(decoded)
+	require.NoError(t, err)
+	result, err := qrcode.NewQRCodeReader().Decode(bmp, map[gozxing.DecodeHintType]interface{}{gozxing.DecodeHintType_PURE_BARCODE: true})
+	require.NoError(t, err)
+
+	// versions 1-9 count in 10 bits numeric and 8 bits byte mode
+	raw := result.GetRawBytes()
+	header := int(raw[0])<<16 | int(raw[1])<<8 | int(raw[2])
+	mode = header >> 20
+	if mode == 1 {
+		count = header >> 10 & 0x3ff
+	} else {
+		count = header >> 12 & 0xff
+	}
+	segments, _ = result.GetResultMetadata()[gozxing.ResultMetadataType_BYTE_SEGMENTS].([][]byte)
+	return mode, count, segments, result.GetText()
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/pnowosie/complete-mnemonic/seedqr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedQR(t *testing.T) {
+	tests := map[string]struct {
+		req             *Request
+		expectedCode    int
+		expectedFormat  string
+		expectedPayload string
+		expectedVersion int
+	}{
+		"standard": {
+			req:             &Request{Op: OpSeedQR, Mnemonic: "forum undo fragile fade shy sign arrest garment culture tube off merit"},
+			expectedCode:    200,
+			expectedFormat:  seedqr.Standard,
+			expectedPayload: "073318950739065415961602009907670428187212261116",
+			expectedVersion: 2,
+		},
+		"compact": {
+			req:             &Request{Op: OpSeedQR, Entropy: "5bbd9d71a8ec7990831aff359d426545", Compact: true},
+			expectedCode:    200,
+			expectedFormat:  seedqr.Compact,
+			expectedPayload: "5bbd9d71a8ec7990831aff359d426545",
+			expectedVersion: 1,
+		},
+		"from phrase": {
+			req:             &Request{Op: OpSeedQR, Phrase: "abandon", Length: 24},
+			expectedCode:    200,
+			expectedFormat:  seedqr.Standard,
+			expectedPayload: strings.Repeat("0000", 23) + "0102",
+			expectedVersion: 3,
+		},
+		"15 words": {
+			req:          &Request{Op: OpSeedQR, Phrase: "abandon", Length: 15},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if test.expectedCode != 200 {
+				return
+			}
+			body := resp.Body.SeedQR
+			assert.Equal(t, test.expectedFormat, body.Format)
+			assert.Equal(t, test.expectedPayload, body.Payload)
+			assert.Equal(t, test.expectedVersion, body.Version)
+			png, err := base64.StdEncoding.DecodeString(body.PNG)
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(string(png), "\x89PNG"))
+			assert.True(t, strings.HasPrefix(body.SVG, "<svg"))
+
+			decoded, err := Main(Request{Op: OpSeedQRDecode, Payload: body.Payload})
+			require.NoError(t, err)
+			assert.Equal(t, 200, decoded.StatusCode, decoded.Body.Error)
+			assert.Equal(t, resp.Body.Mnemonic, decoded.Body.Mnemonic)
+			assert.Equal(t, test.expectedFormat, decoded.Body.SeedQR.Format)
+		})
+	}
+}
+
+func TestSeedQRDecodeInvalid(t *testing.T) {
+	for name, payload := range map[string]string{
+		"wrong checksum": "073318950739065415961602009907670428187212261117",
+		"not hex":        "zz",
+		"odd length":     "5bbd9d71a8ec7990831aff359d42654",
+		"empty":          "",
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(Request{Op: OpSeedQRDecode, Payload: payload})
+			require.NoError(t, err)
+			assert.Equal(t, 400, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pnowosie/complete-mnemonic/bip39"
+	"github.com/pnowosie/complete-mnemonic/codex32"
+	"github.com/pnowosie/complete-mnemonic/slip39"
+)
+
+// splitSlip39 backs up the mnemonic entropy as SLIP-39 share mnemonics.
+func splitSlip39(in Request, mnemonic string, entropy []byte) *Response {
+	groups, err := parseGroups(in.Groups)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	shares, err := slip39.GenerateMnemonics(in.GroupThreshold, groups, entropy, []byte(in.Passphrase), false, slip39.DefaultIterationExponent)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Mnemonic: mnemonic,
+			Length:   len(strings.Fields(mnemonic)),
+			Shares:   shares,
+		},
+	}
+}
+
+// combineSlip39 recovers the mnemonic from SLIP-39 share mnemonics.
+func combineSlip39(in Request) *Response {
+	entropy, err := slip39.CombineMnemonics(splitShares(in.Shares), []byte(in.Passphrase))
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	return recoveredMnemonic(entropy)
+}
+
+// recoveredMnemonic returns the mnemonic of the recovered secret.
+func recoveredMnemonic(entropy []byte) *Response {
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, fmt.Errorf("recovered secret is not BIP-39 entropy: %w", err))
+	}
+
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Mnemonic: mnemonic,
+			Length:   len(strings.Fields(mnemonic)),
+		},
+	}
+}
+
+// splitCodex32 encodes the mnemonic entropy as codex32 string, or splits it into
+// count shares when threshold is given.
+func splitCodex32(in Request, mnemonic string, entropy []byte) *Response {
+	var (
+		strs []string
+		err  error
+	)
+	if in.Threshold == 0 {
+		var str string
+		str, err = codex32.Encode(in.Identifier, entropy)
+		strs = []string{str}
+	} else {
+		strs, err = codex32.Split(in.Identifier, entropy, in.Threshold, in.Count)
+	}
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Mnemonic: mnemonic,
+			Length:   len(strings.Fields(mnemonic)),
+			Codex32:  strs,
+		},
+	}
+}
+
+// combineCodex32 recovers the mnemonic from codex32 strings.
+func combineCodex32(in Request) *Response {
+	entropy, err := codex32.Combine(splitShares(in.Shares))
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	return recoveredMnemonic(entropy)
+}
+
+// splitXor splits the mnemonic into Seed XOR parts, each a valid mnemonic.
+func splitXor(in Request, mnemonic string) *Response {
+	parts, err := bip39.SplitXor(mnemonic, in.Count)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Mnemonic: mnemonic,
+			Length:   len(strings.Fields(mnemonic)),
+			Parts:    parts,
+		},
+	}
+}
+
+// combineXor recovers the mnemonic from Seed XOR parts.
+func combineXor(in Request) *Response {
+	mnemonic, err := bip39.CombineXor(splitShares(in.Shares))
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Mnemonic: mnemonic,
+			Length:   len(strings.Fields(mnemonic)),
+		},
+	}
+}
+
+// parseGroups reads groups like "3of5_2of3", commas separate groups as well.
+func parseGroups(groups string) ([]slip39.Group, error) {
+	parsed := []slip39.Group{}
+	for _, g := range strings.FieldsFunc(groups, func(r rune) bool { return r == '_' || r == ',' }) {
+		var group slip39.Group
+		if _, err := fmt.Sscanf(g, "%dof%d", &group.MemberThreshold, &group.MemberCount); err != nil {
+			return nil, fmt.Errorf("invalid group '%s', expected member threshold and count like 3of5", g)
+		}
+		parsed = append(parsed, group)
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("no groups given")
+	}
+	return parsed, nil
+}
+
+// splitShares reads share mnemonics or codex32 strings separated by new lines
+// or commas, mnemonic words are separated by spaces or _.
+func splitShares(shares string) []string {
+	mnemonics := []string{}
+	for _, share := range strings.FieldsFunc(shares, func(r rune) bool { return r == '\n' || r == ',' }) {
+		if share = strings.TrimSpace(strings.ReplaceAll(share, "_", " ")); share != "" {
+			mnemonics = append(mnemonics, share)
+		}
+	}
+	return mnemonics
+}
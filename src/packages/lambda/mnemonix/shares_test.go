@@ -0,0 +1,240 @@
+package main
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/pnowosie/complete-mnemonic/bip39"
+	"github.com/stretchr/testify/assert"
+)
+
+const slip39Share = "duckling_enlarge_academic_academic_agency_result_length_solution_fridge_kidney_coal_piece_deal_husband_erode_duke_ajar_critical_decision_keyboard"
+
+func TestSlip39Combine(t *testing.T) {
+	entropy, _ := hex.DecodeString("bb54aac4b89dc868ba37d9cc21b2cece")
+	mnemonic, _ := bip39.NewMnemonic(entropy)
+
+	tests := map[string]struct {
+		req              *Request
+		expectedCode     int
+		expectedMnemonic string
+	}{
+		"single share": {
+			req:              &Request{Shares: slip39Share, Passphrase: "TREZOR"},
+			expectedCode:     200,
+			expectedMnemonic: mnemonic,
+		},
+		"invalid checksum": {
+			req:          &Request{Shares: strings.Replace(slip39Share, "keyboard", "kidney", 1), Passphrase: "TREZOR"},
+			expectedCode: 400,
+		},
+		"no shares": {
+			req:          &Request{},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpSlip39Combine
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			assert.Equal(t, test.expectedMnemonic, resp.Body.Mnemonic)
+		})
+	}
+}
+
+func TestSlip39SplitAndCombine(t *testing.T) {
+	tests := map[string]struct {
+		req            *Request
+		expectedGroups []int
+		pick           [][]int
+	}{
+		"single share by default": {
+			req:            &Request{Phrase: "test junk"},
+			expectedGroups: []int{1},
+			pick:           [][]int{{0}},
+		},
+		"2 of 3 groups of 3 of 5": {
+			req:            &Request{Phrase: "angry bird", Length: 24, GroupThreshold: 2, Groups: "3of5_3of5_3of5", Passphrase: "secret"},
+			expectedGroups: []int{5, 5, 5},
+			pick:           [][]int{{4, 0, 2}, nil, {1, 3, 4}},
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpSlip39Split
+		t.Run(name, func(t *testing.T) {
+			split, err := Main(*test.req)
+			assert.NoError(t, err)
+			assert.Equal(t, 200, split.StatusCode, split.Body.Error)
+			assert.Equal(t, len(test.expectedGroups), len(split.Body.Shares))
+
+			picked := []string{}
+			for g, members := range test.pick {
+				assert.Equal(t, test.expectedGroups[g], len(split.Body.Shares[g]))
+				for _, m := range members {
+					picked = append(picked, strings.ReplaceAll(split.Body.Shares[g][m], " ", "_"))
+				}
+			}
+			combined, err := Main(Request{Op: OpSlip39Combine, Shares: strings.Join(picked, "\n"), Passphrase: test.req.Passphrase})
+			assert.NoError(t, err)
+			assert.Equal(t, 200, combined.StatusCode, combined.Body.Error)
+			assert.Equal(t, split.Body.Mnemonic, combined.Body.Mnemonic)
+		})
+	}
+}
+
+func TestSlip39SplitErrors(t *testing.T) {
+	tests := map[string]*Request{
+		"malformed group":       {Phrase: "test junk", Groups: "3-5"},
+		"threshold over groups": {Phrase: "test junk", GroupThreshold: 2, Groups: "2of3"},
+		"member threshold":      {Phrase: "test junk", Groups: "4of3"},
+	}
+
+	for name, req := range tests {
+		req.Op = OpSlip39Split
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*req)
+			assert.NoError(t, err)
+			assert.Equal(t, 400, resp.StatusCode)
+			assert.NotEmpty(t, resp.Body.Error)
+		})
+	}
+}
+
+func TestCodex32Combine(t *testing.T) {
+	entropy, _ := hex.DecodeString("d1808e096b35b209ca12132b264662a5")
+	mnemonic, _ := bip39.NewMnemonic(entropy)
+
+	tests := map[string]struct {
+		req              *Request
+		expectedCode     int
+		expectedMnemonic string
+	}{
+		"secret": {
+			req:              &Request{Shares: "MS12NAMES6XQGUZTTXKEQNJSJZV4JV3NZ5K3KWGSPHUH6EVW"},
+			expectedCode:     200,
+			expectedMnemonic: mnemonic,
+		},
+		"shares": {
+			req:              &Request{Shares: "MS12NAMEA320ZYXWVUTSRQPNMLKJHGFEDCAXRPP870HKKQRM,MS12NAMECACDEFGHJKLMNPQRSTUVWXYZ023FTR2GDZMPY6PN"},
+			expectedCode:     200,
+			expectedMnemonic: mnemonic,
+		},
+		"missing share": {
+			req:          &Request{Shares: "MS12NAMEA320ZYXWVUTSRQPNMLKJHGFEDCAXRPP870HKKQRM"},
+			expectedCode: 400,
+		},
+		"invalid checksum": {
+			req:          &Request{Shares: "MS12NAMES6XQGUZTTXKEQNJSJZV4JV3NZ5K3KWGSPHUH6EVQ"},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpCodex32Combine
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			assert.Equal(t, test.expectedMnemonic, resp.Body.Mnemonic)
+		})
+	}
+}
+
+func TestCodex32SplitAndCombine(t *testing.T) {
+	tests := map[string]struct {
+		req           *Request
+		expectedCount int
+		expectedCode  int
+	}{
+		"unshared secret": {
+			req:           &Request{Phrase: "test junk", Identifier: "junk"},
+			expectedCount: 1,
+			expectedCode:  200,
+		},
+		"2 of 3 shares": {
+			req:           &Request{Phrase: "angry bird", Length: 24, Threshold: 2, Count: 3},
+			expectedCount: 3,
+			expectedCode:  200,
+		},
+		"invalid threshold": {
+			req:          &Request{Phrase: "test junk", Threshold: 1, Count: 3},
+			expectedCode: 400,
+		},
+		"invalid identifier": {
+			req:          &Request{Phrase: "test junk", Identifier: "bio"},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpCodex32Split
+		t.Run(name, func(t *testing.T) {
+			split, err := Main(*test.req)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedCode, split.StatusCode, split.Body.Error)
+			if split.StatusCode != 200 {
+				return
+			}
+			assert.Len(t, split.Body.Codex32, test.expectedCount)
+
+			shares := split.Body.Codex32
+			if test.req.Threshold > 0 {
+				shares = shares[len(shares)-test.req.Threshold:]
+			}
+			combined, err := Main(Request{Op: OpCodex32Combine, Shares: strings.Join(shares, "\n")})
+			assert.NoError(t, err)
+			assert.Equal(t, 200, combined.StatusCode, combined.Body.Error)
+			assert.Equal(t, split.Body.Mnemonic, combined.Body.Mnemonic)
+		})
+	}
+}
+
+func TestXorSplitAndCombine(t *testing.T) {
+	tests := map[string]struct {
+		req           *Request
+		expectedParts int
+		expectedCode  int
+	}{
+		"2 parts by default": {
+			req:           &Request{Phrase: "test junk"},
+			expectedParts: 2,
+			expectedCode:  200,
+		},
+		"4 parts of 24 words": {
+			req:           &Request{Phrase: "angry bird", Length: 24, Count: 4},
+			expectedParts: 4,
+			expectedCode:  200,
+		},
+		"too many parts": {
+			req:          &Request{Phrase: "test junk", Count: 5},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpXorSplit
+		t.Run(name, func(t *testing.T) {
+			split, err := Main(*test.req)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedCode, split.StatusCode, split.Body.Error)
+			if split.StatusCode != 200 {
+				return
+			}
+			assert.Len(t, split.Body.Parts, test.expectedParts)
+
+			parts := make([]string, len(split.Body.Parts))
+			for i, part := range split.Body.Parts {
+				parts[i] = strings.ReplaceAll(part, " ", "_")
+			}
+			combined, err := Main(Request{Op: OpXorCombine, Shares: strings.Join(parts, ",")})
+			assert.NoError(t, err)
+			assert.Equal(t, 200, combined.StatusCode, combined.Body.Error)
+			assert.Equal(t, split.Body.Mnemonic, combined.Body.Mnemonic)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package slip39
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	roundCount     = 4
+	baseIterations = 10000
+)
+
+// encrypt is the Feistel network of the spec, it makes the encrypted master
+// secret which is the one split into shares.
+func encrypt(masterSecret, passphrase []byte, iterationExponent int, identifier uint16, extendable bool) []byte {
+	return feistel(masterSecret, passphrase, iterationExponent, identifier, extendable, []int{0, 1, 2, 3})
+}
+
+func decrypt(encryptedSecret, passphrase []byte, iterationExponent int, identifier uint16, extendable bool) []byte {
+	return feistel(encryptedSecret, passphrase, iterationExponent, identifier, extendable, []int{3, 2, 1, 0})
+}
+
+func feistel(secret, passphrase []byte, iterationExponent int, identifier uint16, extendable bool, rounds []int) []byte {
+	half := len(secret) / 2
+	l := append([]byte{}, secret[:half]...)
+	r := append([]byte{}, secret[half:]...)
+	salt := cipherSalt(identifier, extendable)
+	iterations := (baseIterations << iterationExponent) / roundCount
+
+	for _, i := range rounds {
+		key := append([]byte{byte(i)}, passphrase...)
+		f := pbkdf2.Key(key, append(append([]byte{}, salt...), r...), iterations, len(r), sha256.New)
+		for j := range l {
+			l[j] ^= f[j]
+		}
+		l, r = r, l
+	}
+	return append(r, l...)
+}
+
+func cipherSalt(identifier uint16, extendable bool) []byte {
+	if extendable {
+		return []byte{}
+	}
+	salt := []byte(customizationNonExtendable)
+	return binary.BigEndian.AppendUint16(salt, identifier)
+}
@@ -0,0 +1,54 @@
+package slip39
+
+const (
+	customizationNonExtendable = "shamir"
+	customizationExtendable    = "shamir_extendable"
+
+	checksumWords = 3
+)
+
+var rs1024Generator = [10]uint32{
+	0xe0e040, 0x1c1c080, 0x3838100, 0x7070200, 0xe0e0009,
+	0x1c0c2412, 0x38086c24, 0x3090fc48, 0x21b1f890, 0x3f3f120,
+}
+
+func rs1024Polymod(values []int) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 20
+		chk = (chk&0xfffff)<<10 ^ uint32(v)
+		for i := 0; i < 10; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= rs1024Generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func customization(extendable bool) []int {
+	s := customizationNonExtendable
+	if extendable {
+		s = customizationExtendable
+	}
+	values := make([]int, len(s))
+	for i := range s {
+		values[i] = int(s[i])
+	}
+	return values
+}
+
+// rs1024CreateChecksum returns checksum words of the data words.
+func rs1024CreateChecksum(data []int, extendable bool) []int {
+	values := append(customization(extendable), data...)
+	polymod := rs1024Polymod(append(values, 0, 0, 0)) ^ 1
+	checksum := make([]int, checksumWords)
+	for i := range checksum {
+		checksum[i] = int(polymod>>(10*(checksumWords-1-i))) & 1023
+	}
+	return checksum
+}
+
+func rs1024VerifyChecksum(data []int, extendable bool) bool {
+	return rs1024Polymod(append(customization(extendable), data...)) == 1
+}
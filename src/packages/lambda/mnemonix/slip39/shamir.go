@@ -0,0 +1,139 @@
+package slip39
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+const (
+	maxShareCount = 16
+	digestLength  = 4
+	digestIndex   = 254
+	secretIndex   = 255
+)
+
+var (
+	// exp and log tables of GF(256) with the Rijndael polynomial
+	// x^8 + x^4 + x^3 + x + 1 and the generator 3.
+	expTable [255]byte
+	logTable [256]byte
+)
+
+func init() {
+	poly := 1
+	for i := range expTable {
+		expTable[i] = byte(poly)
+		logTable[poly] = byte(i)
+		// multiply poly by the generator x + 1
+		poly = (poly << 1) ^ poly
+		if poly&0x100 != 0 {
+			poly ^= 0x11b
+		}
+	}
+}
+
+type rawShare struct {
+	x     byte
+	value []byte
+}
+
+// splitSecret splits the secret into shareCount shares, any threshold of them
+// recover it. Shares at indexes 254 and 255 hold the digest and the secret.
+func splitSecret(threshold, shareCount int, secret []byte) ([]rawShare, error) {
+	if threshold < 1 || threshold > shareCount {
+		return nil, fmt.Errorf("threshold must be between 1 and %d", shareCount)
+	}
+	if shareCount > maxShareCount {
+		return nil, fmt.Errorf("share count must not exceed %d", maxShareCount)
+	}
+
+	shares := make([]rawShare, 0, shareCount)
+	if threshold == 1 {
+		for i := 0; i < shareCount; i++ {
+			shares = append(shares, rawShare{x: byte(i), value: append([]byte{}, secret...)})
+		}
+		return shares, nil
+	}
+
+	randomShareCount := threshold - 2
+	for i := 0; i < randomShareCount; i++ {
+		value, err := randomBytes(len(secret))
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, rawShare{x: byte(i), value: value})
+	}
+	randomPart, err := randomBytes(len(secret) - digestLength)
+	if err != nil {
+		return nil, err
+	}
+	digest := createDigest(randomPart, secret)
+
+	base := append([]rawShare{}, shares...)
+	base = append(base,
+		rawShare{x: digestIndex, value: append(digest, randomPart...)},
+		rawShare{x: secretIndex, value: secret},
+	)
+	for i := randomShareCount; i < shareCount; i++ {
+		shares = append(shares, rawShare{x: byte(i), value: interpolate(base, byte(i))})
+	}
+	return shares, nil
+}
+
+// recoverSecret interpolates the secret of threshold shares and checks the digest.
+func recoverSecret(threshold int, shares []rawShare) ([]byte, error) {
+	if threshold == 1 {
+		return shares[0].value, nil
+	}
+	secret := interpolate(shares, secretIndex)
+	digestShare := interpolate(shares, digestIndex)
+	if !hmac.Equal(digestShare[:digestLength], createDigest(digestShare[digestLength:], secret)) {
+		return nil, errors.New("invalid digest of the shared secret")
+	}
+	return secret, nil
+}
+
+// interpolate evaluates at x the Lagrange polynomial going through the shares.
+func interpolate(shares []rawShare, x byte) []byte {
+	for _, s := range shares {
+		if s.x == x {
+			return append([]byte{}, s.value...)
+		}
+	}
+
+	logProd := 0
+	for _, s := range shares {
+		logProd += int(logTable[s.x^x])
+	}
+	result := make([]byte, len(shares[0].value))
+	for _, s := range shares {
+		logBasis := logProd - int(logTable[s.x^x])
+		for _, other := range shares {
+			if other.x != s.x {
+				logBasis -= int(logTable[s.x^other.x])
+			}
+		}
+		logBasis = ((logBasis % 255) + 255) % 255
+		for i, v := range s.value {
+			if v != 0 {
+				result[i] ^= expTable[(int(logTable[v])+logBasis)%255]
+			}
+		}
+	}
+	return result
+}
+
+func createDigest(randomData, secret []byte) []byte {
+	mac := hmac.New(sha256.New, randomData)
+	mac.Write(secret)
+	return mac.Sum(nil)[:digestLength]
+}
+
+func randomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	return b, err
+}
@@ -0,0 +1,121 @@
+package slip39
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	radixBits      = 10
+	idBits         = 15
+	metadataWords  = 7
+	minMnemonicLen = 20
+)
+
+var (
+	// ErrInvalidMnemonic is returned when the share mnemonic is malformed.
+	ErrInvalidMnemonic = errors.New("Invalid share mnemonic")
+
+	// ErrChecksumIncorrect is returned when the share has the incorrect checksum.
+	ErrChecksumIncorrect = errors.New("Share checksum incorrect")
+)
+
+// Share is a single share mnemonic of the master secret.
+type Share struct {
+	Identifier        uint16
+	Extendable        bool
+	IterationExponent int
+	GroupIndex        int
+	GroupThreshold    int
+	GroupCount        int
+	MemberIndex       int
+	MemberThreshold   int
+	Value             []byte
+}
+
+// Mnemonic encodes the share into words of the WordList.
+func (s Share) Mnemonic() string {
+	ext := 0
+	if s.Extendable {
+		ext = 1
+	}
+	idExp := int(s.Identifier)<<5 | ext<<4 | s.IterationExponent
+	params := s.GroupIndex<<16 | (s.GroupThreshold-1)<<12 | (s.GroupCount-1)<<8 | s.MemberIndex<<4 | (s.MemberThreshold - 1)
+
+	data := []int{idExp >> radixBits, idExp & 1023, params >> radixBits, params & 1023}
+	data = append(data, valueToIndexes(s.Value)...)
+	data = append(data, rs1024CreateChecksum(data, s.Extendable)...)
+
+	words := make([]string, len(data))
+	for i, index := range data {
+		words[i] = WordList[index]
+	}
+	return strings.Join(words, " ")
+}
+
+// ParseShare decodes the share mnemonic and verifies its checksum.
+func ParseShare(mnemonic string) (Share, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) < minMnemonicLen {
+		return Share{}, fmt.Errorf("%w: expected at least %d words", ErrInvalidMnemonic, minMnemonicLen)
+	}
+	data := make([]int, len(words))
+	for i, word := range words {
+		index, ok := wordMap[word]
+		if !ok {
+			return Share{}, fmt.Errorf("%w: word '%s' at position %d is not in WordList", ErrInvalidMnemonic, word, i)
+		}
+		data[i] = index
+	}
+
+	valueWords := len(data) - metadataWords
+	paddingBits := (radixBits * valueWords) % 16
+	if paddingBits > 8 {
+		return Share{}, fmt.Errorf("%w: invalid length", ErrInvalidMnemonic)
+	}
+
+	idExp := data[0]<<radixBits | data[1]
+	s := Share{
+		Identifier:        uint16(idExp >> 5),
+		Extendable:        (idExp>>4)&1 == 1,
+		IterationExponent: idExp & 15,
+	}
+	if !rs1024VerifyChecksum(data, s.Extendable) {
+		return Share{}, ErrChecksumIncorrect
+	}
+
+	params := data[2]<<radixBits | data[3]
+	s.GroupIndex = params >> 16
+	s.GroupThreshold = (params>>12)&15 + 1
+	s.GroupCount = (params>>8)&15 + 1
+	s.MemberIndex = (params >> 4) & 15
+	s.MemberThreshold = params&15 + 1
+	if s.GroupThreshold > s.GroupCount {
+		return Share{}, fmt.Errorf("%w: group threshold %d exceeds group count %d", ErrInvalidMnemonic, s.GroupThreshold, s.GroupCount)
+	}
+
+	value := new(big.Int)
+	for _, index := range data[4 : len(data)-checksumWords] {
+		value.Lsh(value, radixBits).Or(value, big.NewInt(int64(index)))
+	}
+	valueBytes := (radixBits*valueWords - paddingBits) / 8
+	if value.BitLen() > valueBytes*8 {
+		return Share{}, fmt.Errorf("%w: invalid padding", ErrInvalidMnemonic)
+	}
+	s.Value = value.FillBytes(make([]byte, valueBytes))
+	return s, nil
+}
+
+// valueToIndexes splits the value into 10-bit words, padded with zero bits on the left.
+func valueToIndexes(value []byte) []int {
+	count := (len(value)*8 + radixBits - 1) / radixBits
+	v := new(big.Int).SetBytes(value)
+	indexes := make([]int, count)
+	for i := count - 1; i >= 0; i-- {
+		indexes[i] = int(v.Int64() & 1023)
+		v.Rsh(v, radixBits)
+	}
+	return indexes
+}
@@ -0,0 +1,164 @@
+package slip39
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	// DefaultIterationExponent sets 20000 PBKDF2 iterations of the cipher.
+	DefaultIterationExponent = 1
+
+	minSecretLength = 16
+)
+
+var (
+	// ErrSecretLengthInvalid is returned when the master secret has an invalid size.
+	ErrSecretLengthInvalid = errors.New("Master secret length must be at least 128 bits and a multiple of 16")
+
+	// ErrPassphraseInvalid is returned when the passphrase has non printable ASCII characters.
+	ErrPassphraseInvalid = errors.New("Passphrase must consist of printable ASCII characters")
+)
+
+// Group of shares, any MemberThreshold of its MemberCount shares recover the group.
+type Group struct {
+	MemberThreshold int
+	MemberCount     int
+}
+
+// GenerateMnemonics splits the master secret into groups of share mnemonics.
+// Any groupThreshold of groups recover the secret.
+func GenerateMnemonics(groupThreshold int, groups []Group, masterSecret, passphrase []byte, extendable bool, iterationExponent int) ([][]string, error) {
+	if len(masterSecret) < minSecretLength || len(masterSecret)%2 != 0 {
+		return nil, ErrSecretLengthInvalid
+	}
+	if err := validatePassphrase(passphrase); err != nil {
+		return nil, err
+	}
+	if groupThreshold < 1 || groupThreshold > len(groups) {
+		return nil, fmt.Errorf("group threshold must be between 1 and %d", len(groups))
+	}
+	for _, g := range groups {
+		if g.MemberThreshold == 1 && g.MemberCount > 1 {
+			return nil, errors.New("creating multiple member shares with member threshold 1 is not allowed, use 1-of-1 member sharing instead")
+		}
+	}
+	if iterationExponent < 0 || iterationExponent > 15 {
+		return nil, errors.New("iteration exponent must be between 0 and 15")
+	}
+
+	var id [2]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, err
+	}
+	identifier := binary.BigEndian.Uint16(id[:]) & (1<<idBits - 1)
+
+	encrypted := encrypt(masterSecret, passphrase, iterationExponent, identifier, extendable)
+	groupShares, err := splitSecret(groupThreshold, len(groups), encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonics := make([][]string, len(groups))
+	for i, g := range groups {
+		memberShares, err := splitSecret(g.MemberThreshold, g.MemberCount, groupShares[i].value)
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", i+1, err)
+		}
+		for _, m := range memberShares {
+			mnemonics[i] = append(mnemonics[i], Share{
+				Identifier:        identifier,
+				Extendable:        extendable,
+				IterationExponent: iterationExponent,
+				GroupIndex:        int(groupShares[i].x),
+				GroupThreshold:    groupThreshold,
+				GroupCount:        len(groups),
+				MemberIndex:       int(m.x),
+				MemberThreshold:   g.MemberThreshold,
+				Value:             m.value,
+			}.Mnemonic())
+		}
+	}
+	return mnemonics, nil
+}
+
+// CombineMnemonics recovers the master secret of the share mnemonics. Shares
+// beyond the thresholds are not needed and are left out.
+func CombineMnemonics(mnemonics []string, passphrase []byte) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, errors.New("no share mnemonics given")
+	}
+	if err := validatePassphrase(passphrase); err != nil {
+		return nil, err
+	}
+
+	var first Share
+	groups := map[int][]Share{}
+	for i, mnemonic := range mnemonics {
+		s, err := ParseShare(mnemonic)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		if i == 0 {
+			first = s
+		}
+		if s.Identifier != first.Identifier || s.Extendable != first.Extendable || s.IterationExponent != first.IterationExponent ||
+			s.GroupThreshold != first.GroupThreshold || s.GroupCount != first.GroupCount || len(s.Value) != len(first.Value) {
+			return nil, fmt.Errorf("share %d does not belong to the same secret", i+1)
+		}
+		for _, other := range groups[s.GroupIndex] {
+			if other.MemberIndex == s.MemberIndex {
+				return nil, fmt.Errorf("share %d is a duplicate of member %d of group %d", i+1, s.MemberIndex+1, s.GroupIndex+1)
+			}
+			if other.MemberThreshold != s.MemberThreshold {
+				return nil, fmt.Errorf("share %d has member threshold different than others of group %d", i+1, s.GroupIndex+1)
+			}
+		}
+		groups[s.GroupIndex] = append(groups[s.GroupIndex], s)
+	}
+
+	indexes := make([]int, 0, len(groups))
+	for index := range groups {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	groupShares := []rawShare{}
+	for _, index := range indexes {
+		members := groups[index]
+		threshold := members[0].MemberThreshold
+		if len(members) < threshold || len(groupShares) == first.GroupThreshold {
+			continue
+		}
+		raw := make([]rawShare, threshold)
+		for i, m := range members[:threshold] {
+			raw[i] = rawShare{x: byte(m.MemberIndex), value: m.Value}
+		}
+		secret, err := recoverSecret(threshold, raw)
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %w", index+1, err)
+		}
+		groupShares = append(groupShares, rawShare{x: byte(index), value: secret})
+	}
+	if len(groupShares) < first.GroupThreshold {
+		return nil, fmt.Errorf("insufficient shares, %d of %d groups are complete", len(groupShares), first.GroupThreshold)
+	}
+
+	encrypted, err := recoverSecret(first.GroupThreshold, groupShares)
+	if err != nil {
+		return nil, err
+	}
+	return decrypt(encrypted, passphrase, first.IterationExponent, first.Identifier, first.Extendable), nil
+}
+
+func validatePassphrase(passphrase []byte) error {
+	for _, c := range passphrase {
+		if c < 32 || c > 126 {
+			return ErrPassphraseInvalid
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package slip39
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// VectorsFile holds the official test vectors, see
+// https://github.com/trezor/python-shamir-mnemonic/blob/master/vectors.json
+// Each vector is [description, mnemonics, master secret, xprv], the master
+// secret is empty for invalid mnemonics. All use "TREZOR" passphrase.
+const (
+	VectorsFile       = "testdata/vectors.json"
+	VectorsPassphrase = "TREZOR"
+)
+
+func TestWordList(t *testing.T) {
+	assert.Len(t, WordList, 1024)
+	prefixes := map[string]bool{}
+	for i, word := range WordList {
+		if i > 0 {
+			assert.Less(t, WordList[i-1], word)
+		}
+		prefixes[word[:4]] = true
+	}
+	assert.Len(t, prefixes, 1024, "words must have unique 4 letter prefixes")
+}
+
+func TestVectors(t *testing.T) {
+	fbytes, err := os.ReadFile(VectorsFile)
+	if err != nil {
+		t.Fatal("Cannot open vectors file", "file", VectorsFile, err)
+	}
+	vectors := [][3]json.RawMessage{}
+	if err := json.Unmarshal(fbytes, &vectors); err != nil {
+		t.Fatal("Cannot parse vectors file", "file", VectorsFile, err)
+	}
+
+	for _, vector := range vectors {
+		var (
+			description, secretHex string
+			mnemonics              []string
+		)
+		_ = json.Unmarshal(vector[0], &description)
+		_ = json.Unmarshal(vector[1], &mnemonics)
+		_ = json.Unmarshal(vector[2], &secretHex)
+
+		t.Run(description, func(t *testing.T) {
+			secret, err := CombineMnemonics(mnemonics, []byte(VectorsPassphrase))
+			if secretHex == "" {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, secretHex, hex.EncodeToString(secret))
+		})
+	}
+}
+
+func TestGenerateAndCombine(t *testing.T) {
+	secret, _ := hex.DecodeString("0c1e24e5917779d297e14d45f14e1a1a")
+	tests := map[string]struct {
+		groupThreshold int
+		groups         []Group
+		pick           func(groups [][]string) []string
+		extendable     bool
+	}{
+		"1-of-1": {
+			groupThreshold: 1,
+			groups:         []Group{{1, 1}},
+			pick:           func(g [][]string) []string { return g[0] },
+		},
+		"3-of-5 members": {
+			groupThreshold: 1,
+			groups:         []Group{{3, 5}},
+			pick:           func(g [][]string) []string { return []string{g[0][4], g[0][1], g[0][2]} },
+		},
+		"2-of-3 groups of 3-of-5 members": {
+			groupThreshold: 2,
+			groups:         []Group{{3, 5}, {3, 5}, {3, 5}},
+			pick: func(g [][]string) []string {
+				return []string{g[2][0], g[0][3], g[2][4], g[0][1], g[2][2], g[0][0]}
+			},
+		},
+		"extra shares": {
+			groupThreshold: 2,
+			groups:         []Group{{1, 1}, {2, 3}, {2, 3}},
+			pick:           func(g [][]string) []string { return append(append([]string{}, g[1]...), g[0]...) },
+			extendable:     true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			groups, err := GenerateMnemonics(test.groupThreshold, test.groups, secret, []byte("TREZOR"), test.extendable, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, g := range test.groups {
+				assert.Len(t, groups[i], g.MemberCount)
+				for _, mnemonic := range groups[i] {
+					assert.Len(t, strings.Fields(mnemonic), 20)
+				}
+			}
+
+			recovered, err := CombineMnemonics(test.pick(groups), []byte("TREZOR"))
+			assert.NoError(t, err)
+			assert.Equal(t, secret, recovered)
+
+			other, err := CombineMnemonics(test.pick(groups), []byte("wrong"))
+			assert.NoError(t, err, "passphrase is plausibly deniable")
+			assert.False(t, bytes.Equal(secret, other))
+		})
+	}
+}
+
+func TestCombineErrors(t *testing.T) {
+	secret := bytes.Repeat([]byte{0xab}, 32)
+	groups, err := GenerateMnemonics(2, []Group{{2, 3}, {2, 3}}, secret, nil, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	others, _ := GenerateMnemonics(1, []Group{{2, 3}}, secret, nil, false, 0)
+
+	tests := map[string][]string{
+		"missing group":    {groups[0][0], groups[0][1]},
+		"missing member":   {groups[0][0], groups[0][1], groups[1][0]},
+		"duplicate member": {groups[0][0], groups[0][0], groups[1][0], groups[1][1]},
+		"other secret":     {groups[0][0], groups[0][1], others[0][0]},
+		"unknown word":     {strings.Replace(groups[0][0], " ", " bitcoin ", 1)},
+		"no shares":        {},
+	}
+	for name, mnemonics := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := CombineMnemonics(mnemonics, nil)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	secret := bytes.Repeat([]byte{1}, 16)
+	_, err := GenerateMnemonics(1, []Group{{1, 1}}, secret[:15], nil, false, 0)
+	assert.ErrorIs(t, err, ErrSecretLengthInvalid)
+	_, err = GenerateMnemonics(1, []Group{{1, 1}}, secret, []byte("pässwörd"), false, 0)
+	assert.ErrorIs(t, err, ErrPassphraseInvalid)
+	_, err = GenerateMnemonics(2, []Group{{1, 1}}, secret, nil, false, 0)
+	assert.Error(t, err)
+	_, err = GenerateMnemonics(1, []Group{{1, 3}}, secret, nil, false, 0)
+	assert.Error(t, err)
+	_, err = GenerateMnemonics(1, []Group{{3, 17}}, secret, nil, false, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+const (
+	wordBits = 11
+
+	// minSequence is the shortest run of equally spaced words worth a warning
+	minSequence = 4
+)
+
+// analyzeMnemonic estimates the entropy as the cheapest way to guess the
+// mnemonic. Like the README counts it for phrases, every chosen word is worth
+// 11 bits, including the last one.
+func analyzeMnemonic(in Request) *Response {
+	words := strings.Fields(in.Mnemonic)
+	analysis := analyze(words)
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Wallet: WalletBody{
+				Mnemonic:   in.Mnemonic,
+				Derivation: in.Derivation,
+				Length:     in.Length,
+			},
+			Analysis: analysis,
+		},
+	}
+}
+
+func analyze(words []string) *AnalysisBody {
+	var (
+		n        = len(words)
+		maxBits  = n*wordBits - n/3
+		body     = words[:n-1]
+		distinct = distinctWords(words)
+		period   = repetitionPeriod(body)
+		sequence = longestSequence(body)
+		bits     = maxBits
+		warnings = []string{}
+	)
+
+	if distinct == 1 {
+		warnings = append(warnings, fmt.Sprintf("single word '%s' repeated, it is a lucky word of the samples", words[0]))
+	} else if period == 1 {
+		warnings = append(warnings, fmt.Sprintf("word '%s' repeated with a checksum word, it is one of the samples", words[0]))
+	} else if period > 0 {
+		warnings = append(warnings, fmt.Sprintf("words repeat every %d words", period))
+	}
+	if period > 0 {
+		bits = minInt(bits, (period+1)*wordBits)
+	}
+
+	if distinct <= n/2 {
+		warnings = append(warnings, fmt.Sprintf("only %d distinct words out of %d", distinct, n))
+		// choice of the words and their arrangement
+		arrangement := int(math.Ceil(float64(n) * math.Log2(float64(distinct))))
+		bits = minInt(bits, distinct*wordBits+arrangement)
+	}
+
+	if sequence < minSequence {
+		sequence = 0
+	} else {
+		warnings = append(warnings, fmt.Sprintf("%d words are equally spaced in the wordlist", sequence))
+		if sequence == len(body) {
+			// the first word, the step and the last word
+			bits = minInt(bits, 3*wordBits)
+		}
+	}
+
+	if bits < maxBits {
+		warnings = append(warnings, fmt.Sprintf("effective entropy is about %d bits, a random mnemonic has %d bits", bits, maxBits))
+	}
+
+	return &AnalysisBody{
+		Words:          n,
+		DistinctWords:  distinct,
+		Period:         period,
+		Sequence:       sequence,
+		EntropyBits:    bits,
+		MaxEntropyBits: maxBits,
+		Score:          bits * 100 / maxBits,
+		Warnings:       warnings,
+	}
+}
+
+func distinctWords(words []string) int {
+	seen := map[string]bool{}
+	for _, word := range words {
+		seen[word] = true
+	}
+	return len(seen)
+}
+
+// repetitionPeriod returns the shortest pattern length repeated at least twice
+// over the words, zero when the words don't repeat.
+func repetitionPeriod(words []string) int {
+	for k := 1; 2*k <= len(words); k++ {
+		repeated := true
+		for i := k; i < len(words) && repeated; i++ {
+			repeated = words[i] == words[i%k]
+		}
+		if repeated {
+			return k
+		}
+	}
+	return 0
+}
+
+// longestSequence returns the longest run of words whose wordlist indexes
+// differ by the same, non zero step.
+func longestSequence(words []string) int {
+	if len(words) < 2 {
+		return len(words)
+	}
+	indexes := make([]int, len(words))
+	for i, word := range words {
+		indexes[i], _ = bip39.GetWordIndex(word)
+	}
+
+	longest, run := 0, 1
+	for i := 1; i < len(indexes); i++ {
+		step := indexes[i] - indexes[i-1]
+		switch {
+		case step == 0:
+			run = 1
+		case i >= 2 && step == indexes[i-1]-indexes[i-2]:
+			run++
+		default:
+			run = 2
+		}
+		longest = maxInt(longest, run)
+	}
+	return longest
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
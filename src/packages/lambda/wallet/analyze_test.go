@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := map[string]struct {
+		req              *Request
+		expectedAnalysis *AnalysisBody
+	}{
+		"lucky word": {
+			req: &Request{Mnemonic: "action action action action action action action action action action action action"},
+			expectedAnalysis: &AnalysisBody{
+				Words: 12, DistinctWords: 1, Period: 1, EntropyBits: 11, MaxEntropyBits: 128, Score: 8,
+				Warnings: []string{
+					"single word 'action' repeated, it is a lucky word of the samples",
+					"only 1 distinct words out of 12",
+					"effective entropy is about 11 bits, a random mnemonic has 128 bits",
+				},
+			},
+		},
+		"single word sample": {
+			req: &Request{Phrase: "abandon about"},
+			expectedAnalysis: &AnalysisBody{
+				Words: 12, DistinctWords: 2, Period: 1, EntropyBits: 22, MaxEntropyBits: 128, Score: 17,
+				Warnings: []string{
+					"word 'abandon' repeated with a checksum word, it is one of the samples",
+					"only 2 distinct words out of 12",
+					"effective entropy is about 22 bits, a random mnemonic has 128 bits",
+				},
+			},
+		},
+		"three words phrase as in README": {
+			req: &Request{Phrase: "alien alert alley"},
+			expectedAnalysis: &AnalysisBody{
+				Words: 12, DistinctWords: 3, Period: 2, EntropyBits: 33, MaxEntropyBits: 128, Score: 25,
+				Warnings: []string{
+					"words repeat every 2 words",
+					"only 3 distinct words out of 12",
+					"effective entropy is about 33 bits, a random mnemonic has 128 bits",
+				},
+			},
+		},
+		"wordlist order": {
+			req: &Request{Mnemonic: "abandon ability able about above absent absorb abstract absurd abuse access ability"},
+			expectedAnalysis: &AnalysisBody{
+				Words: 12, DistinctWords: 11, Sequence: 11, EntropyBits: 33, MaxEntropyBits: 128, Score: 25,
+				Warnings: []string{
+					"11 words are equally spaced in the wordlist",
+					"effective entropy is about 33 bits, a random mnemonic has 128 bits",
+				},
+			},
+		},
+		"random": {
+			req: &Request{Mnemonic: "panda eyebrow bullet gorilla call smoke muffin taste mesh discover soft ostrich alcohol speed nation flash devote level hobby quick inner drive ghost inside"},
+			expectedAnalysis: &AnalysisBody{
+				Words: 24, DistinctWords: 24, EntropyBits: 256, MaxEntropyBits: 256, Score: 100,
+				Warnings: []string{},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpAnalyze
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, 200, resp.StatusCode, resp.Body.Error)
+			assert.Equal(t, test.expectedAnalysis, resp.Body.Analysis)
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	bip39 "github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
+)
+
+const (
+	bip85Purpose = 83696968
+	bip85HMACKey = "bip-entropy-from-k"
+
+	bip85AppBIP39    = 39
+	bip85AppWIF      = 2
+	bip85AppHex      = 128169
+	bip85AppPassword = 707764
+)
+
+// bip85Language is a wordlist with its word separator, Japanese mnemonics are
+// separated by ideographic space.
+type bip85Language struct {
+	name      string
+	words     []string
+	separator string
+}
+
+// bip85Languages are wordlists by their BIP-85 language code.
+var bip85Languages = []bip85Language{
+	{"english", wordlists.English, " "},
+	{"japanese", wordlists.Japanese, "\u3000"},
+	{"korean", wordlists.Korean, " "},
+	{"spanish", wordlists.Spanish, " "},
+	{"chinese_simplified", wordlists.ChineseSimplified, " "},
+	{"chinese_traditional", wordlists.ChineseTraditional, " "},
+	{"french", wordlists.French, " "},
+	{"italian", wordlists.Italian, " "},
+	{"czech", wordlists.Czech, " "},
+}
+
+// deriveBIP85 derives the child secret of the app at the request index, so one
+// master mnemonic reproduces any number of independent mnemonics and secrets.
+func deriveBIP85(in Request) *Response {
+	seed, err := newSeed(in.Mnemonic, in.Password)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, err)
+	}
+	child, err := bip85Child(master, in)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Wallet: WalletBody{
+				Mnemonic:   in.Mnemonic,
+				Derivation: in.Derivation,
+				Length:     in.Length,
+			},
+			BIP85: child,
+		},
+	}
+}
+
+func bip85Child(master *hdkeychain.ExtendedKey, in Request) (*BIP85Body, error) {
+	if err := validateIndex(in.Index); err != nil {
+		return nil, err
+	}
+	index := uint32(in.Index)
+
+	switch in.App {
+	case AppBIP39:
+		language := -1
+		for code, l := range bip85Languages {
+			if l.name == in.Language {
+				language = code
+			}
+		}
+		if language < 0 {
+			return nil, fmt.Errorf("invalid language '%s'", in.Language)
+		}
+		if in.Words != 12 && in.Words != 18 && in.Words != 24 {
+			return nil, fmt.Errorf("invalid words %d, accepted values: 12, 18, 24", in.Words)
+		}
+		path := []uint32{bip85AppBIP39, uint32(language), uint32(in.Words), index}
+		entropy, err := bip85Entropy(master, path)
+		if err != nil {
+			return nil, err
+		}
+		mnemonic, err := translateMnemonic(entropy[:in.Words*4/3], bip85Languages[language])
+		if err != nil {
+			return nil, err
+		}
+		return &BIP85Body{App: in.App, Path: bip85Path(path), Language: in.Language, Mnemonic: mnemonic}, nil
+
+	case AppHex:
+		if in.Size < 16 || in.Size > 64 {
+			return nil, fmt.Errorf("invalid size %d, hex has 16 to 64 bytes", in.Size)
+		}
+		path := []uint32{bip85AppHex, uint32(in.Size), index}
+		entropy, err := bip85Entropy(master, path)
+		if err != nil {
+			return nil, err
+		}
+		return &BIP85Body{App: in.App, Path: bip85Path(path), Hex: hex.EncodeToString(entropy[:in.Size])}, nil
+
+	case AppWIF:
+		path := []uint32{bip85AppWIF, index}
+		entropy, err := bip85Entropy(master, path)
+		if err != nil {
+			return nil, err
+		}
+		key, _ := btcec.PrivKeyFromBytes(btcec.S256(), entropy[:32])
+		wif, err := btcutil.NewWIF(key, &chaincfg.MainNetParams, true)
+		if err != nil {
+			return nil, err
+		}
+		return &BIP85Body{App: in.App, Path: bip85Path(path), WIF: wif.String()}, nil
+
+	case AppPassword:
+		if in.Size < 20 || in.Size > 86 {
+			return nil, fmt.Errorf("invalid size %d, password has 20 to 86 characters", in.Size)
+		}
+		path := []uint32{bip85AppPassword, uint32(in.Size), index}
+		entropy, err := bip85Entropy(master, path)
+		if err != nil {
+			return nil, err
+		}
+		password := base64.StdEncoding.EncodeToString(entropy)[:in.Size]
+		return &BIP85Body{App: in.App, Path: bip85Path(path), Password: password}, nil
+	}
+	return nil, fmt.Errorf("invalid app '%s', accepted values: %s, %s, %s, %s", in.App, AppBIP39, AppHex, AppWIF, AppPassword)
+}
+
+// bip85Entropy derives the hardened path of the BIP-85 purpose and returns the
+// HMAC-SHA512 of the private key.
+func bip85Entropy(master *hdkeychain.ExtendedKey, path []uint32) ([]byte, error) {
+	key, err := master.Derive(hdkeychain.HardenedKeyStart + bip85Purpose)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range path {
+		if key, err = key.Derive(hdkeychain.HardenedKeyStart + i); err != nil {
+			return nil, err
+		}
+	}
+	priv, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha512.New, []byte(bip85HMACKey))
+	mac.Write(priv.Serialize())
+	return mac.Sum(nil), nil
+}
+
+func bip85Path(path []uint32) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "m/%d'", bip85Purpose)
+	for _, i := range path {
+		fmt.Fprintf(&sb, "/%d'", i)
+	}
+	return sb.String()
+}
+
+// translateMnemonic returns the mnemonic of the entropy in the language.
+func translateMnemonic(entropy []byte, language bip85Language) (string, error) {
+	english, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", err
+	}
+	words := strings.Fields(english)
+	for i, word := range words {
+		index, _ := bip39.GetWordIndex(word)
+		words[i] = language.words[index]
+	}
+	return strings.Join(words, language.separator), nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/stretchr/testify/assert"
+	bip39 "github.com/tyler-smith/go-bip39"
+	"golang.org/x/text/unicode/norm"
+)
+
+// BIP85MasterKey is the master key of the BIP-85 test vectors, see
+// https://github.com/bitcoin/bips/blob/master/bip-0085.mediawiki#test-vectors
+const BIP85MasterKey = "xprv9s21ZrQH143K2LBWUUQRFXhucrQqBpKdRRxNVq2zBqsx8HVqFk2uYo8kmbaLLHRdqtQpUm98uKfu3vca1LqdGhUtyoFnCNkfmXRyPXLjbKb"
+
+func TestBIP85Entropy(t *testing.T) {
+	master, err := hdkeychain.NewKeyFromString(BIP85MasterKey)
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		path            []uint32
+		expectedEntropy string
+	}{
+		"test case 1": {
+			path:            []uint32{0, 0},
+			expectedEntropy: "efecfbccffea313214232d29e71563d941229afb4338c21f9517c41aaa0d16f00b83d2a09ef747e7a64e8e2bd5a14869e693da66ce94ac2da570ab7ee48618f7",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			entropy, err := bip85Entropy(master, test.path)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedEntropy, hex.EncodeToString(entropy))
+		})
+	}
+}
+
+func TestBIP85Child(t *testing.T) {
+	master, err := hdkeychain.NewKeyFromString(BIP85MasterKey)
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		req      Request
+		expected BIP85Body
+	}{
+		"12 words": {
+			req: Request{App: AppBIP39, Language: "english", Words: 12},
+			expected: BIP85Body{App: AppBIP39, Path: "m/83696968'/39'/0'/12'/0'", Language: "english",
+				Mnemonic: "girl mad pet galaxy egg matter matrix prison refuse sense ordinary nose"},
+		},
+		"18 words": {
+			req: Request{App: AppBIP39, Language: "english", Words: 18},
+			expected: BIP85Body{App: AppBIP39, Path: "m/83696968'/39'/0'/18'/0'", Language: "english",
+				Mnemonic: "near account window bike charge season chef number sketch tomorrow excuse sniff circle vital hockey outdoor supply token"},
+		},
+		"24 words": {
+			req: Request{App: AppBIP39, Language: "english", Words: 24},
+			expected: BIP85Body{App: AppBIP39, Path: "m/83696968'/39'/0'/24'/0'", Language: "english",
+				Mnemonic: "puppy ocean match cereal symbol another shed magic wrap hammer bulb intact gadget divorce twin tonight reason outdoor destroy simple truth cigar social volcano"},
+		},
+		"hex": {
+			req: Request{App: AppHex, Size: 64},
+			expected: BIP85Body{App: AppHex, Path: "m/83696968'/128169'/64'/0'",
+				Hex: "492db4698cf3b73a5a24998aa3e9d7fa96275d85724a91e71aa2d645442f878555d078fd1f1f67e368976f04137b1f7a0d19232136ca50c44614af72b5582a5c"},
+		},
+		"wif": {
+			req:      Request{App: AppWIF},
+			expected: BIP85Body{App: AppWIF, Path: "m/83696968'/2'/0'", WIF: "Kzyv4uF39d4Jrw2W7UryTHwZr1zQVNk4dAFyqE6BuMrMh1Za7uhp"},
+		},
+		"password": {
+			req:      Request{App: AppPassword, Size: 21},
+			expected: BIP85Body{App: AppPassword, Path: "m/83696968'/707764'/21'/0'", Password: "dKLoepugzdVJvdL56ogNV"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			child, err := bip85Child(master, test.req)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, *child)
+		})
+	}
+}
+
+func TestDeriveBIP85(t *testing.T) {
+	tests := map[string]struct {
+		req          *Request
+		expectedCode int
+		expectedPath string
+	}{
+		"child mnemonic by default": {
+			req:          &Request{Phrase: "test junk"},
+			expectedCode: 200,
+			expectedPath: "m/83696968'/39'/0'/12'/0'",
+		},
+		"japanese 24 words": {
+			req:          &Request{Phrase: "test junk", Language: "japanese", Words: 24, Index: 3},
+			expectedCode: 200,
+			expectedPath: "m/83696968'/39'/1'/24'/3'",
+		},
+		"hex by default 32 bytes": {
+			req:          &Request{Phrase: "test junk", App: AppHex},
+			expectedCode: 200,
+			expectedPath: "m/83696968'/128169'/32'/0'",
+		},
+		"unknown language": {
+			req:          &Request{Phrase: "test junk", Language: "klingon"},
+			expectedCode: 400,
+		},
+		"invalid words": {
+			req:          &Request{Phrase: "test junk", Words: 15},
+			expectedCode: 400,
+		},
+		"short password": {
+			req:          &Request{Phrase: "test junk", App: AppPassword, Size: 8},
+			expectedCode: 400,
+		},
+		"negative index": {
+			req:          &Request{Phrase: "test junk", Index: -1},
+			expectedCode: 400,
+		},
+		"index wrapping to zero": {
+			req:          &Request{Phrase: "test junk", Index: 1 << 32},
+			expectedCode: 400,
+		},
+		"index wrapping below hardened": {
+			req:          &Request{Phrase: "test junk", Index: 4294967301},
+			expectedCode: 400,
+		},
+		"unknown app": {
+			req:          &Request{Phrase: "test junk", App: "rsa"},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpBIP85
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if resp.StatusCode != 200 {
+				return
+			}
+			assert.Equal(t, test.expectedPath, resp.Body.BIP85.Path)
+
+			again, _ := Main(*test.req)
+			assert.Equal(t, resp.Body.BIP85, again.Body.BIP85, "derivation is deterministic")
+		})
+	}
+}
+
+func TestTranslateMnemonic(t *testing.T) {
+	entropy := make([]byte, 16)
+	mnemonic, err := translateMnemonic(entropy, bip85Languages[1])
+	assert.NoError(t, err)
+	assert.Contains(t, mnemonic, "\u3000")
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	assert.Len(t, words, 12)
+	assert.Equal(t, norm.NFKD.String("あいこくしん"), words[0])
+	assert.Equal(t, norm.NFKD.String("あおぞら"), words[11])
+
+	english, _ := translateMnemonic(entropy, bip85Languages[0])
+	assert.True(t, bip39.IsMnemonicValid(english))
+}
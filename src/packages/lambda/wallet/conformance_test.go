@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/hdkeychain"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+	hd "github.com/miguelmota/go-ethereum-hdwallet"
+	"github.com/stretchr/testify/assert"
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+const (
+	// TrezorVectorsFile holds the vectors of the vendored bip39 package of
+	// mnemonix, each vector is [entropy, mnemonic, seed, xprv] with "TREZOR"
+	// passphrase.
+	TrezorVectorsFile = "../mnemonix/bip39/testdata/vectors.json"
+	TrezorPassphrase  = "TREZOR"
+
+	// BIP32VectorsFile holds test vectors 1-4 of
+	// https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki#test-vectors
+	BIP32VectorsFile = "testdata/bip32_vectors.json"
+)
+
+type bip32Vector struct {
+	Seed   string `json:"seed"`
+	Chains []struct {
+		Path string `json:"path"`
+		XPub string `json:"xpub"`
+		XPrv string `json:"xprv"`
+	} `json:"chains"`
+}
+
+func readJSON(t *testing.T, file string, v any) {
+	fbytes, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal("Cannot open vectors file", "file", file, err)
+	}
+	if err := json.Unmarshal(fbytes, v); err != nil {
+		t.Fatal("Cannot parse vectors file", "file", file, err)
+	}
+}
+
+func TestTrezorVectorsConformance(t *testing.T) {
+	vectors := map[string][][4]string{}
+	readJSON(t, TrezorVectorsFile, &vectors)
+
+	// go-bip39 supports other languages, wallet function speaks english only
+	for _, vector := range vectors["english"] {
+		entropyHex, mnemonic, seedHex, xprv := vector[0], vector[1], vector[2], vector[3]
+		t.Run(entropyHex, func(t *testing.T) {
+			entropy, _ := hex.DecodeString(entropyHex)
+			mn, err := bip39.NewMnemonic(entropy)
+			assert.NoError(t, err)
+			assert.Equal(t, mnemonic, mn)
+
+			seed := bip39.NewSeed(mnemonic, TrezorPassphrase)
+			assert.Equal(t, seedHex, hex.EncodeToString(seed))
+
+			master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+			assert.NoError(t, err)
+			assert.Equal(t, xprv, master.String())
+		})
+	}
+}
+
+func TestBIP32VectorsConformance(t *testing.T) {
+	vectors := []bip32Vector{}
+	readJSON(t, BIP32VectorsFile, &vectors)
+
+	for _, vector := range vectors {
+		seed, _ := hex.DecodeString(vector.Seed)
+		wallet, err := walletFromSeed(seed, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, chain := range vector.Chains {
+			t.Run(vector.Seed[:8]+" "+chain.Path, func(t *testing.T) {
+				expected, err := hdkeychain.NewKeyFromString(chain.XPrv)
+				assert.NoError(t, err)
+				expectedPub, _ := expected.Neuter()
+				assert.Equal(t, chain.XPub, expectedPub.String())
+
+				if chain.Path == "m" {
+					master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+					assert.NoError(t, err)
+					assert.Equal(t, chain.XPrv, master.String())
+					return
+				}
+
+				path, err := accounts.ParseDerivationPath(chain.Path)
+				assert.NoError(t, err)
+				account, err := wallet.Derive(path, false)
+				assert.NoError(t, err)
+				privateKey, err := wallet.PrivateKey(account)
+				assert.NoError(t, err)
+
+				expectedKey, _ := expected.ECPrivKey()
+				assert.Equal(t, hex.EncodeToString(expectedKey.Serialize()), hex.EncodeToString(crypto.FromECDSA(privateKey)))
+			})
+		}
+	}
+}
+
+// BIP-32 vector 4 has leading zero byte at m/0', the legacy derivation of
+// btcutil issue #172 drops it and derives another m/0'/1'.
+func TestLegacyDerivationDiffersOnVector4(t *testing.T) {
+	seed, _ := hex.DecodeString("3ddd5602285899a946114506157c7997e5444528f3003f6134712147db19b678")
+	path := accounts.DerivationPath{hdkeychain.HardenedKeyStart, hdkeychain.HardenedKeyStart + 1}
+
+	keys := make([]string, 2)
+	for i, fix := range []bool{false, true} {
+		wallet, err := hd.NewFromSeed(seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wallet.SetFixIssue172(fix)
+		account, err := wallet.Derive(path, false)
+		assert.NoError(t, err)
+		keys[i], _ = wallet.PrivateKeyHex(account)
+	}
+	assert.NotEqual(t, keys[0], keys[1])
+
+	for i, strict := range []bool{false, true} {
+		wallet, _ := walletFromSeed(seed, strict)
+		account, _ := wallet.Derive(path, false)
+		key, _ := wallet.PrivateKeyHex(account)
+		assert.Equal(t, keys[i], key)
+	}
+}
+
+// The key at m/44'/60' of this wallet has a leading zero byte. Derivation
+// affected by btcutil issue #172 is kept unless strict is requested.
+func TestLeadingZeroKeyDerivation(t *testing.T) {
+	tests := map[string]struct {
+		strict          bool
+		expectedAddress string
+	}{
+		"legacy": {false, "0x3B8bd3EE1014b2d6039b1fb478d108405187D12f"},
+		"strict": {true, "0x079ee229F2f65C59A9aa52B93795fFf5A3F075c3"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := Main(Request{
+				Mnemonic: "test test test test test test test test test test test junk",
+				Password: "pässwörd",
+				Count:    1,
+				Strict:   tc.strict,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, 200, res.StatusCode)
+			assert.Equal(t, tc.expectedAddress, res.Body.Accounts[0].Address)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalletFromEntropy(t *testing.T) {
+	tests := map[string]struct {
+		req              *Request
+		expectedCode     int
+		expectedMnemonic string
+		expectedLength   int
+		expectedEntropy  *EntropyBody
+	}{
+		"hex": {
+			req:              &Request{Entropy: strings.Repeat("7f", 16)},
+			expectedCode:     200,
+			expectedMnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			expectedLength:   12,
+			expectedEntropy:  &EntropyBody{Format: EntropyHex, Symbols: 32, SuppliedBits: 128, UsedBits: 128},
+		},
+		"coins of 24 words": {
+			req:              &Request{Entropy: strings.Repeat("t", 256), EntropyFormat: EntropyCoins, Length: 24},
+			expectedCode:     200,
+			expectedMnemonic: strings.Repeat("abandon ", 23) + "art",
+			expectedLength:   24,
+			expectedEntropy:  &EntropyBody{Format: EntropyCoins, Symbols: 256, SuppliedBits: 256, UsedBits: 256},
+		},
+		"not enough rolls": {
+			req:          &Request{Entropy: strings.Repeat("5", 100), EntropyFormat: EntropyDice},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Count = 1
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			assert.Equal(t, test.expectedMnemonic, resp.Body.Wallet.Mnemonic)
+			assert.Equal(t, test.expectedLength, resp.Body.Wallet.Length)
+			assert.Equal(t, test.expectedEntropy, resp.Body.Entropy)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// outputFormats render derived accounts into a file content tooling consumes
+// as is. The content is returned in the output of the response body.
+var outputFormats = map[string]func(in Request, accs []AccountBody, keys []*ecdsa.PrivateKey) (string, error){
+	FormatGeth:    gethGenesis,
+	FormatAnvil:   anvilState,
+	FormatHardhat: hardhatConfig,
+	FormatFoundry: foundryProfile,
+	FormatEnv:     envFile,
+	FormatCSV:     csvFile,
+	FormatSVG:     paperSVG,
+	FormatPDF:     paperPDF,
+}
+
+func validateFormat(in Request) error {
+	if _, ok := outputFormats[in.Format]; !ok {
+		names := make([]string, 0, len(outputFormats))
+		for name := range outputFormats {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("invalid format '%s', accepted values: %s", in.Format, strings.Join(names, ", "))
+	}
+	switch in.Format {
+	case FormatGeth, FormatAnvil:
+		return validateGenesis(in)
+	}
+	return nil
+}
+
+// hardhatConfig is the hardhat network config deriving the same accounts.
+func hardhatConfig(in Request, _ []AccountBody, _ []*ecdsa.PrivateKey) (string, error) {
+	var b strings.Builder
+	b.WriteString("module.exports = {\n")
+	b.WriteString("  networks: {\n")
+	b.WriteString("    hardhat: {\n")
+	b.WriteString("      accounts: {\n")
+	fmt.Fprintf(&b, "        mnemonic: %s,\n", strconv.Quote(in.Mnemonic))
+	fmt.Fprintf(&b, "        path: %s,\n", strconv.Quote(strings.TrimSuffix(in.Derivation, "/")))
+	b.WriteString("        initialIndex: 0,\n")
+	fmt.Fprintf(&b, "        count: %d,\n", in.Count)
+	if in.Password != "" {
+		fmt.Fprintf(&b, "        passphrase: %s,\n", strconv.Quote(in.Password))
+	}
+	b.WriteString("      },\n")
+	b.WriteString("    },\n")
+	b.WriteString("  },\n")
+	b.WriteString("};\n")
+	return b.String(), nil
+}
+
+// foundryProfile sets the first account as the default sender. Foundry takes
+// mnemonics from the command line only, the comment shows the flags.
+func foundryProfile(in Request, accs []AccountBody, _ []*ecdsa.PrivateKey) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# forge script --mnemonics %s --mnemonic-derivation-paths \"%s0\"\n", strconv.Quote(in.Mnemonic), in.Derivation)
+	if in.Password != "" {
+		fmt.Fprintf(&b, "#   --mnemonic-passphrases %s\n", strconv.Quote(in.Password))
+	}
+	b.WriteString("[profile.wallet]\n")
+	fmt.Fprintf(&b, "sender = %s\n", strconv.Quote(accs[0].Address))
+	fmt.Fprintf(&b, "tx_origin = %s\n", strconv.Quote(accs[0].Address))
+	return b.String(), nil
+}
+
+// envFile lists addresses of the accounts. Private keys are listed only when
+// revealed.
+func envFile(in Request, accs []AccountBody, _ []*ecdsa.PrivateKey) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "MNEMONIC=%s\n", strconv.Quote(in.Mnemonic))
+	fmt.Fprintf(&b, "DERIVATION_PATH=%s\n", strconv.Quote(in.Derivation))
+	for i, acc := range accs {
+		fmt.Fprintf(&b, "ADDRESS_%d=%s\n", i, acc.Address)
+		if acc.PrivateKey != "" {
+			fmt.Fprintf(&b, "PRIVATE_KEY_%d=0x%s\n", i, acc.PrivateKey)
+		}
+	}
+	return b.String(), nil
+}
+
+// csvFile lists the accounts with their public keys.
+func csvFile(in Request, accs []AccountBody, keys []*ecdsa.PrivateKey) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"index", "path", "address", "publicKey"})
+	for i, acc := range accs {
+		_ = w.Write([]string{
+			strconv.Itoa(i),
+			fmt.Sprintf("%s%d", in.Derivation, i),
+			acc.Address,
+			hex.EncodeToString(crypto.FromECDSAPub(&keys[i].PublicKey)[1:]),
+		})
+	}
+	w.Flush()
+	return buf.String(), w.Error()
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputFormats(t *testing.T) {
+	tests := map[string]struct {
+		req            *Request
+		expectedOutput string
+	}{
+		"hardhat": {
+			req: &Request{Format: FormatHardhat, Password: "pass"},
+			expectedOutput: `module.exports = {
+  networks: {
+    hardhat: {
+      accounts: {
+        mnemonic: "test test test test test test test test test test test junk",
+        path: "m/44'/60'/0'/0",
+        initialIndex: 0,
+        count: 2,
+        passphrase: "pass",
+      },
+    },
+  },
+};
+`,
+		},
+		"foundry": {
+			req: &Request{Format: FormatFoundry},
+			expectedOutput: `# forge script --mnemonics "test test test test test test test test test test test junk" --mnemonic-derivation-paths "m/44'/60'/0'/0/0"
+[profile.wallet]
+sender = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+tx_origin = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+`,
+		},
+		"env without private keys": {
+			req: &Request{Format: FormatEnv},
+			expectedOutput: `MNEMONIC="test test test test test test test test test test test junk"
+DERIVATION_PATH="m/44'/60'/0'/0/"
+ADDRESS_0=0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266
+ADDRESS_1=0x70997970C51812dc3A010C7d01b50e0d17dc79C8
+`,
+		},
+		"env with private keys": {
+			req: &Request{Format: FormatEnv, RevealPrivate: true},
+			expectedOutput: `MNEMONIC="test test test test test test test test test test test junk"
+DERIVATION_PATH="m/44'/60'/0'/0/"
+ADDRESS_0=0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266
+PRIVATE_KEY_0=0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80
+ADDRESS_1=0x70997970C51812dc3A010C7d01b50e0d17dc79C8
+PRIVATE_KEY_1=0x59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d
+`,
+		},
+		"csv": {
+			req: &Request{Format: FormatCSV},
+			expectedOutput: `index,path,address,publicKey
+0,m/44'/60'/0'/0/0,0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266,8318535b54105d4a7aae60c08fc45f9687181b4fdfc625bd1a753fa7397fed753547f11ca8696646f2f3acb08e31016afac23e630c5d11f59f61fef57b0d2aa5
+1,m/44'/60'/0'/0/1,0x70997970C51812dc3A010C7d01b50e0d17dc79C8,ba5734d8f7091719471e7f7ed6b9df170dc70cc661ca05e688601ad984f068b0d67351e5f06073092499336ab0839ef8a521afd334e53807205fa2f08eec74f4
+`,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Phrase = "test junk"
+		test.req.Count = 2
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, 200, resp.StatusCode, resp.Body.Error)
+			assert.Equal(t, test.expectedOutput, resp.Body.Output)
+		})
+	}
+}
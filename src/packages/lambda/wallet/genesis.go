@@ -0,0 +1,153 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	ForkLondon   = "london"
+	ForkParis    = "paris"
+	ForkShanghai = "shanghai"
+	ForkCancun   = "cancun"
+
+	genesisGasLimit = 30_000_000
+	genesisBaseFee  = 1_000_000_000
+)
+
+// forks lists the forks in activation order. Genesis config activates the
+// chosen fork at genesis together with all the preceding ones.
+var forks = []string{ForkLondon, ForkParis, ForkShanghai, ForkCancun}
+
+// londonConfig activates all the block numbered forks up to london.
+var londonConfig = []string{
+	"homesteadBlock", "eip150Block", "eip155Block", "eip158Block", "byzantiumBlock",
+	"constantinopleBlock", "petersburgBlock", "istanbulBlock", "berlinBlock", "londonBlock",
+}
+
+type genesisAccount struct {
+	Balance string `json:"balance"`
+}
+
+// genesisFile is the genesis.json which geth init reads. Besu and anvil --init
+// accept it as well.
+type genesisFile struct {
+	Config     map[string]interface{}    `json:"config"`
+	Nonce      string                    `json:"nonce"`
+	Timestamp  string                    `json:"timestamp"`
+	ExtraData  string                    `json:"extraData"`
+	GasLimit   string                    `json:"gasLimit"`
+	Difficulty string                    `json:"difficulty"`
+	BaseFee    string                    `json:"baseFeePerGas"`
+	Alloc      map[string]genesisAccount `json:"alloc"`
+}
+
+// anvilAccount is an account of the state anvil --load-state reads.
+type anvilAccount struct {
+	Nonce   uint64            `json:"nonce"`
+	Balance string            `json:"balance"`
+	Code    string            `json:"code"`
+	Storage map[string]string `json:"storage"`
+}
+
+func validateGenesis(in Request) error {
+	if _, err := parseAmount("balance", in.Balance); err != nil {
+		return err
+	}
+	if in.Format == FormatAnvil {
+		if in.ChainID != 0 || in.Fork != "" {
+			return fmt.Errorf("anvil state has no chain config, use anvil --chain-id and --hardfork flags instead")
+		}
+		return nil
+	}
+	if in.ChainID < 0 {
+		return fmt.Errorf("invalid chainId %d", in.ChainID)
+	}
+	if forkIndex(in.Fork) < 0 {
+		return fmt.Errorf("invalid fork '%s', accepted values: %s", in.Fork, strings.Join(forks, ", "))
+	}
+	return nil
+}
+
+func gethGenesis(in Request, accs []AccountBody, _ []*ecdsa.PrivateKey) (string, error) {
+	balance, err := parseAmount("balance", in.Balance)
+	if err != nil {
+		return "", err
+	}
+
+	config := map[string]interface{}{"chainId": in.ChainID}
+	for _, name := range londonConfig {
+		config[name] = 0
+	}
+	fork := forkIndex(in.Fork)
+	difficulty := "0x1"
+	if fork >= forkIndex(ForkParis) {
+		config["terminalTotalDifficulty"] = 0
+		config["terminalTotalDifficultyPassed"] = true
+		config["mergeNetsplitBlock"] = 0
+		difficulty = "0x0"
+	} else {
+		config["ethash"] = struct{}{}
+	}
+	if fork >= forkIndex(ForkShanghai) {
+		config["shanghaiTime"] = 0
+	}
+	if fork >= forkIndex(ForkCancun) {
+		config["cancunTime"] = 0
+	}
+
+	genesis := genesisFile{
+		Config:     config,
+		Nonce:      "0x0",
+		Timestamp:  "0x0",
+		ExtraData:  "0x",
+		GasLimit:   hexutil.EncodeUint64(genesisGasLimit),
+		Difficulty: difficulty,
+		BaseFee:    hexutil.EncodeUint64(genesisBaseFee),
+		Alloc:      map[string]genesisAccount{},
+	}
+	for _, acc := range accs {
+		genesis.Alloc[acc.Address] = genesisAccount{Balance: hexutil.EncodeBig(balance)}
+	}
+	return marshalOutput(genesis)
+}
+
+func anvilState(in Request, accs []AccountBody, _ []*ecdsa.PrivateKey) (string, error) {
+	balance, err := parseAmount("balance", in.Balance)
+	if err != nil {
+		return "", err
+	}
+
+	state := struct {
+		Accounts map[string]anvilAccount `json:"accounts"`
+	}{map[string]anvilAccount{}}
+	for _, acc := range accs {
+		state.Accounts[strings.ToLower(acc.Address)] = anvilAccount{
+			Balance: hexutil.EncodeBig(balance),
+			Code:    "0x",
+			Storage: map[string]string{},
+		}
+	}
+	return marshalOutput(state)
+}
+
+func forkIndex(name string) int {
+	for i, fork := range forks {
+		if fork == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func marshalOutput(v interface{}) (string, error) {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(out) + "\n", nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGethGenesis(t *testing.T) {
+	tests := map[string]struct {
+		req                *Request
+		expectedCode       int
+		expectedChainID    float64
+		expectedBalance    string
+		expectedDifficulty string
+		expectedConfig     []string
+		unexpectedConfig   []string
+	}{
+		"defaults": {
+			req:                &Request{},
+			expectedCode:       200,
+			expectedChainID:    1337,
+			expectedBalance:    "0x21e19e0c9bab2400000",
+			expectedDifficulty: "0x0",
+			expectedConfig:     []string{"londonBlock", "terminalTotalDifficulty", "shanghaiTime"},
+			unexpectedConfig:   []string{"cancunTime", "ethash"},
+		},
+		"london with chain id": {
+			req:                &Request{Fork: ForkLondon, ChainID: 31337, Balance: "0x3e8"},
+			expectedCode:       200,
+			expectedChainID:    31337,
+			expectedBalance:    "0x3e8",
+			expectedDifficulty: "0x1",
+			expectedConfig:     []string{"londonBlock", "ethash"},
+			unexpectedConfig:   []string{"terminalTotalDifficulty", "shanghaiTime"},
+		},
+		"cancun": {
+			req:                &Request{Fork: ForkCancun, Balance: "1000"},
+			expectedCode:       200,
+			expectedChainID:    1337,
+			expectedBalance:    "0x3e8",
+			expectedDifficulty: "0x0",
+			expectedConfig:     []string{"shanghaiTime", "cancunTime"},
+		},
+		"unknown fork": {
+			req:          &Request{Fork: "prague"},
+			expectedCode: 400,
+		},
+		"invalid balance": {
+			req:          &Request{Balance: "lots"},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Phrase = "test junk"
+		test.req.Count = 3
+		test.req.Format = FormatGeth
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if resp.StatusCode != 200 {
+				return
+			}
+
+			var genesis struct {
+				Config     map[string]interface{}       `json:"config"`
+				Difficulty string                       `json:"difficulty"`
+				Alloc      map[string]map[string]string `json:"alloc"`
+			}
+			assert.NoError(t, json.Unmarshal([]byte(resp.Body.Output), &genesis))
+			assert.Equal(t, test.expectedChainID, genesis.Config["chainId"])
+			assert.Equal(t, test.expectedDifficulty, genesis.Difficulty)
+			for _, key := range test.expectedConfig {
+				assert.Contains(t, genesis.Config, key)
+			}
+			for _, key := range test.unexpectedConfig {
+				assert.NotContains(t, genesis.Config, key)
+			}
+			assert.Len(t, genesis.Alloc, 3)
+			for _, acc := range resp.Body.Accounts {
+				assert.Equal(t, test.expectedBalance, genesis.Alloc[acc.Address]["balance"])
+			}
+		})
+	}
+}
+
+func TestAnvilState(t *testing.T) {
+	tests := map[string]struct {
+		req          *Request
+		expectedCode int
+	}{
+		"defaults": {
+			req:          &Request{},
+			expectedCode: 200,
+		},
+		"chain id": {
+			req:          &Request{ChainID: 31337},
+			expectedCode: 400,
+		},
+		"fork": {
+			req:          &Request{Fork: ForkCancun},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Phrase = "test junk"
+		test.req.Count = 2
+		test.req.Format = FormatAnvil
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if resp.StatusCode != 200 {
+				return
+			}
+
+			var state struct {
+				Accounts map[string]anvilAccount `json:"accounts"`
+			}
+			assert.NoError(t, json.Unmarshal([]byte(resp.Body.Output), &state))
+			assert.Len(t, state.Accounts, 2)
+			assert.Equal(t, "0x21e19e0c9bab2400000", state.Accounts["0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"].Balance)
+		})
+	}
+}
+
+func TestInvalidFormat(t *testing.T) {
+	resp, err := Main(Request{Phrase: "test junk", Format: "besu"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Equal(t, "invalid format 'besu', accepted values: anvil, csv, env, foundry, geth, hardhat, pdf, svg", resp.Body.Error)
+}
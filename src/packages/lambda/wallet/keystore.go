@@ -0,0 +1,234 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+const (
+	keystoreVersion = 3
+
+	// scrypt parameters of the geth light KDF, the standard ones need 256MB
+	// of memory while the function is limited to 128MB
+	keystoreScryptN = keystore.LightScryptN
+	keystoreScryptP = keystore.LightScryptP
+
+	keystorePBKDF2Iterations = 1 << 18
+	keystoreKeyLength        = 32
+)
+
+// keystoreV3 is the Web3 Secret Storage Definition, see
+// https://ethereum.org/en/developers/docs/data-structures-and-encoding/web3-secret-storage/
+type keystoreV3 struct {
+	Address string              `json:"address"`
+	Crypto  keystore.CryptoJSON `json:"crypto"`
+	ID      string              `json:"id"`
+	Version int                 `json:"version"`
+}
+
+func validateExport(in Request) error {
+	if in.Export != ExportKeystore {
+		return fmt.Errorf("invalid export '%s', accepted values: %s", in.Export, ExportKeystore)
+	}
+	if in.KDF != KDFScrypt && in.KDF != KDFPBKDF2 {
+		return fmt.Errorf("invalid kdf '%s', accepted values: %s, %s", in.KDF, KDFScrypt, KDFPBKDF2)
+	}
+	if in.KeystorePassword == "" {
+		return errors.New("keystorePassword is required to export keystore")
+	}
+	if in.RevealPrivate {
+		return errors.New("keystore export never reveals private keys, remove 'reveal' parameter")
+	}
+	return nil
+}
+
+// importKeystore decrypts the keystore and optionally re-encrypts it with
+// the kdf and the new password, when given.
+func importKeystore(in Request) *Response {
+	if err := checkKDFCost([]byte(in.Keystore)); err != nil {
+		return errorResponse(http.StatusBadRequest, fmt.Errorf("cannot decrypt keystore: %w", err))
+	}
+	key, err := keystore.DecryptKey([]byte(in.Keystore), in.KeystorePassword)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, fmt.Errorf("cannot decrypt keystore: %w", err))
+	}
+
+	acc := AccountBody{Address: key.Address.Hex()}
+	if in.RevealPrivate {
+		acc.PublicKey = hex.EncodeToString(crypto.FromECDSAPub(&key.PrivateKey.PublicKey)[1:])
+		acc.PrivateKey = hex.EncodeToString(crypto.FromECDSA(key.PrivateKey))
+	}
+	if in.Export != "" {
+		if in.NewPassword != "" {
+			in.KeystorePassword = in.NewPassword
+		}
+		if err := validateExport(in); err != nil {
+			return errorResponse(http.StatusBadRequest, err)
+		}
+		if acc.Keystore, err = encryptKeystore(key.PrivateKey, in.KeystorePassword, in.KDF); err != nil {
+			return errorResponse(http.StatusInternalServerError, err)
+		}
+	}
+
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Accounts: []AccountBody{acc},
+		},
+	}
+}
+
+// scrypt needs 128*n*r bytes of memory and about 0.5us per n*r*p on a single
+// core. Up to 64MB is left of the function's 128MB limit, so the geth standard
+// parameters, n=262144 and r=8, which need 256MB cannot be decrypted, the test
+// vector with r=1 and p=8 can. Decryption up to 2s leaves time to respond
+// within 3500ms function limit.
+const (
+	keystoreScryptCost        = 500 * time.Nanosecond
+	keystoreMaxScryptDuration = 2 * time.Second
+	keystoreMaxScryptMemory   = 64 << 20
+	keystoreMaxScryptWork     = int(keystoreMaxScryptDuration / keystoreScryptCost)
+)
+
+// kdfLimit is the accepted range of the kdf parameter.
+type kdfLimit struct {
+	name     string
+	min, max int
+}
+
+// kdfLimits bound each kdf parameter, scrypt costs are checked further by
+// checkScryptCost. Decryption needs the derived key of 32 bytes exactly.
+var kdfLimits = map[string][]kdfLimit{
+	KDFScrypt: {{"n", 2, keystoreMaxScryptWork}, {"r", 1, keystoreMaxScryptWork}, {"p", 1, keystoreMaxScryptWork}, {"dklen", keystoreKeyLength, keystoreKeyLength}},
+	KDFPBKDF2: {{"c", 1, keystorePBKDF2Iterations}, {"dklen", keystoreKeyLength, keystoreKeyLength}},
+}
+
+// checkKDFCost rejects the keystore before decryption when its kdf parameters
+// are out of the limits. Keystores of other kdfs are left to the decryption.
+func checkKDFCost(data []byte) error {
+	var ks keystoreV3
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return err
+	}
+	params := map[string]int{}
+	for _, param := range kdfLimits[ks.Crypto.KDF] {
+		value, ok := ks.Crypto.KDFParams[param.name].(float64)
+		if !ok {
+			return fmt.Errorf("%s parameter '%s' must be a number", ks.Crypto.KDF, param.name)
+		}
+		if value < float64(param.min) || value > float64(param.max) {
+			return fmt.Errorf("%s parameter '%s' is %g, accepted values: %d-%d", ks.Crypto.KDF, param.name, value, param.min, param.max)
+		}
+		params[param.name] = int(value)
+	}
+	if ks.Crypto.KDF == KDFScrypt {
+		return checkScryptCost(params["n"], params["r"], params["p"])
+	}
+	return nil
+}
+
+// checkScryptCost rejects scrypt parameters exceeding the function's memory or
+// time limit. The parameters are at most keystoreMaxScryptWork each.
+func checkScryptCost(n, r, p int) error {
+	if memory := int64(128) * int64(n) * int64(r); memory > keystoreMaxScryptMemory {
+		return fmt.Errorf("scrypt n=%d r=%d needs %dMB, above the function's memory limit of %dMB",
+			n, r, memory>>20, keystoreMaxScryptMemory>>20)
+	}
+	if work := int64(n) * int64(r) * int64(p); work > int64(keystoreMaxScryptWork) {
+		return fmt.Errorf("scrypt n=%d r=%d p=%d takes about %v, above the %v limit",
+			n, r, p, time.Duration(work)*keystoreScryptCost, keystoreMaxScryptDuration)
+	}
+	return nil
+}
+
+func exportKeystores(accs []AccountBody, keys []*ecdsa.PrivateKey, password, kdf string) error {
+	for i, key := range keys {
+		encrypted, err := encryptKeystore(key, password, kdf)
+		if err != nil {
+			return err
+		}
+		accs[i].Keystore = encrypted
+	}
+	return nil
+}
+
+func encryptKeystore(key *ecdsa.PrivateKey, password, kdf string) (json.RawMessage, error) {
+	var (
+		keyBytes = math.PaddedBigBytes(key.D, keystoreKeyLength)
+		cryptoV3 keystore.CryptoJSON
+		err      error
+	)
+	switch kdf {
+	case KDFScrypt:
+		cryptoV3, err = keystore.EncryptDataV3(keyBytes, []byte(password), keystoreScryptN, keystoreScryptP)
+	case KDFPBKDF2:
+		cryptoV3, err = encryptDataPBKDF2(keyBytes, []byte(password))
+	default:
+		err = fmt.Errorf("unsupported kdf '%s'", kdf)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	return json.Marshal(keystoreV3{
+		Address: hex.EncodeToString(address[:]),
+		Crypto:  cryptoV3,
+		ID:      id.String(),
+		Version: keystoreVersion,
+	})
+}
+
+// encryptDataPBKDF2 mirrors keystore.EncryptDataV3 which supports scrypt only.
+func encryptDataPBKDF2(data, password []byte) (keystore.CryptoJSON, error) {
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(salt); err != nil {
+		return keystore.CryptoJSON{}, err
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return keystore.CryptoJSON{}, err
+	}
+
+	derivedKey := pbkdf2.Key(password, salt, keystorePBKDF2Iterations, keystoreKeyLength, sha256.New)
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return keystore.CryptoJSON{}, err
+	}
+	cipherText := make([]byte, len(data))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, data)
+
+	cryptoV3 := keystore.CryptoJSON{
+		Cipher:     "aes-128-ctr",
+		CipherText: hex.EncodeToString(cipherText),
+		KDF:        KDFPBKDF2,
+		KDFParams: map[string]interface{}{
+			"c":     keystorePBKDF2Iterations,
+			"dklen": keystoreKeyLength,
+			"prf":   "hmac-sha256",
+			"salt":  hex.EncodeToString(salt),
+		},
+		MAC: hex.EncodeToString(crypto.Keccak256(derivedKey[16:32], cipherText)),
+	}
+	cryptoV3.CipherParams.IV = hex.EncodeToString(iv)
+	return cryptoV3, nil
+}
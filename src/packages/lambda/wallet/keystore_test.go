@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/hex"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeystoreExport(t *testing.T) {
+	expected := []struct{ address, privateKey string }{
+		{"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"},
+		{"0x70997970C51812dc3A010C7d01b50e0d17dc79C8", "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d"},
+	}
+
+	for _, kdf := range []string{KDFScrypt, KDFPBKDF2} {
+		t.Run(kdf, func(t *testing.T) {
+			resp, err := Main(Request{
+				Phrase:           "test junk",
+				Count:            2,
+				Export:           ExportKeystore,
+				KeystorePassword: "testtest",
+				KDF:              kdf,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, 200, resp.StatusCode, resp.Body.Error)
+
+			for i, acc := range resp.Body.Accounts {
+				assert.Equal(t, expected[i].address, acc.Address)
+				assert.Empty(t, acc.PrivateKey)
+
+				key, err := keystore.DecryptKey(acc.Keystore, "testtest")
+				assert.NoError(t, err)
+				assert.Equal(t, expected[i].address, key.Address.Hex())
+				assert.Equal(t, expected[i].privateKey, hex.EncodeToString(crypto.FromECDSA(key.PrivateKey)))
+
+				_, err = keystore.DecryptKey(acc.Keystore, "wrong")
+				assert.ErrorIs(t, err, keystore.ErrDecrypt)
+			}
+		})
+	}
+}
+
+func TestKeystoreExportErrors(t *testing.T) {
+	tests := map[string]struct {
+		req           *Request
+		expectedError string
+	}{
+		"missing password": {
+			req:           &Request{Export: ExportKeystore},
+			expectedError: "keystorePassword is required to export keystore",
+		},
+		"unknown kdf": {
+			req:           &Request{Export: ExportKeystore, KeystorePassword: "x", KDF: "argon2"},
+			expectedError: "invalid kdf 'argon2', accepted values: scrypt, pbkdf2",
+		},
+		"unknown export": {
+			req:           &Request{Export: "pem", KeystorePassword: "x"},
+			expectedError: "invalid export 'pem', accepted values: keystore",
+		},
+		"reveal private keys": {
+			req:           &Request{Export: ExportKeystore, KeystorePassword: "x", RevealPrivate: true},
+			expectedError: "keystore export never reveals private keys, remove 'reveal' parameter",
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Phrase = "test junk"
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, 400, resp.StatusCode)
+			assert.Equal(t, test.expectedError, resp.Body.Error)
+		})
+	}
+}
+
+// Test vectors of the Web3 Secret Storage Definition, the key address is
+// 0x008AeEda4D805471dF9b2A5B0f38A0C3bCBA786b. The light scrypt keystore is of
+// the same key and password, made with the scrypt parameters of the function.
+const (
+	lightKeystoreVector  = `{"address":"008aeeda4d805471df9b2a5b0f38a0c3bcba786b","crypto":{"cipher":"aes-128-ctr","ciphertext":"7bde595ca2dbade8e876c09cf85408777c702a3758f08b73b7cb9bc60dc064a7","cipherparams":{"iv":"f4c91248453565dbbd82b6896c9c8678"},"kdf":"scrypt","kdfparams":{"dklen":32,"n":4096,"p":6,"r":8,"salt":"1ae0700f1f84c3097e385af6ae991d3c76036325ec40e426209b745bd045dd3d"},"mac":"cb59581772fd435f3ef36b82fa7d1f48081f352f9a5a1351eb47e4418bc67fee"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	scryptKeystoreVector = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"83dbcc02d8ccb40e466191a123791e0e"},"ciphertext":"d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c","kdf":"scrypt","kdfparams":{"dklen":32,"n":262144,"r":1,"p":8,"salt":"ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},"mac":"2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	pbkdf2KeystoreVector = `{"crypto":{"cipher":"aes-128-ctr","cipherparams":{"iv":"6087dab2f9fdbbfaddc31a909735c1e6"},"ciphertext":"5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46","kdf":"pbkdf2","kdfparams":{"c":262144,"dklen":32,"prf":"hmac-sha256","salt":"ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},"mac":"517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"},"id":"3198bc9c-6672-5ab3-d995-4942343ae5b6","version":3}`
+	vectorPassword       = "testpassword"
+	vectorAddress        = "0x008AeEda4D805471dF9b2A5B0f38A0C3bCBA786b"
+	vectorPrivateKey     = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+)
+
+func TestKeystoreImport(t *testing.T) {
+	tests := map[string]struct {
+		req          *Request
+		expectedCode int
+		reencrypted  bool
+	}{
+		"light scrypt": {
+			req:          &Request{Keystore: lightKeystoreVector, KeystorePassword: vectorPassword},
+			expectedCode: 200,
+		},
+		"scrypt test vector": {
+			req:          &Request{Keystore: scryptKeystoreVector, KeystorePassword: vectorPassword},
+			expectedCode: 200,
+		},
+		"scrypt above time limit": {
+			req:          &Request{Keystore: strings.Replace(scryptKeystoreVector, `"p":8`, `"p":32`, 1), KeystorePassword: vectorPassword},
+			expectedCode: 400,
+		},
+		"pbkdf2 above iterations limit": {
+			req:          &Request{Keystore: strings.Replace(pbkdf2KeystoreVector, `"c":262144`, `"c":262145`, 1), KeystorePassword: vectorPassword},
+			expectedCode: 400,
+		},
+		"short derived key": {
+			req:          &Request{Keystore: strings.Replace(pbkdf2KeystoreVector, `"dklen":32`, `"dklen":16`, 1), KeystorePassword: vectorPassword},
+			expectedCode: 400,
+		},
+		"kdf parameter not a number": {
+			req:          &Request{Keystore: strings.Replace(lightKeystoreVector, `"n":4096`, `"n":"4096"`, 1), KeystorePassword: vectorPassword},
+			expectedCode: 400,
+		},
+		"pbkdf2 with private key": {
+			req:          &Request{Keystore: pbkdf2KeystoreVector, KeystorePassword: vectorPassword, RevealPrivate: true},
+			expectedCode: 200,
+		},
+		"re-encrypt with new password": {
+			req: &Request{
+				Keystore:         pbkdf2KeystoreVector,
+				KeystorePassword: vectorPassword,
+				Export:           ExportKeystore,
+				KDF:              KDFScrypt,
+				NewPassword:      "changed",
+			},
+			expectedCode: 200,
+			reencrypted:  true,
+		},
+		"wrong password": {
+			req:          &Request{Keystore: pbkdf2KeystoreVector, KeystorePassword: "password"},
+			expectedCode: 400,
+		},
+		"malformed keystore": {
+			req:          &Request{Keystore: `{"version":3}`, KeystorePassword: vectorPassword},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if test.expectedCode != 200 {
+				return
+			}
+
+			acc := resp.Body.Accounts[0]
+			assert.Equal(t, vectorAddress, acc.Address)
+			if test.req.RevealPrivate {
+				assert.Equal(t, vectorPrivateKey, acc.PrivateKey)
+			}
+			if !test.reencrypted {
+				assert.Empty(t, acc.Keystore)
+				return
+			}
+			key, err := keystore.DecryptKey(acc.Keystore, test.req.NewPassword)
+			assert.NoError(t, err)
+			assert.Equal(t, vectorPrivateKey, hex.EncodeToString(crypto.FromECDSA(key.PrivateKey)))
+		})
+	}
+}
+
+// geth encrypts keystores with n=262144 and r=8 by default, which need more
+// memory than the function has.
+func TestKeystoreImportGethStandard(t *testing.T) {
+	raw, err := os.ReadFile("testdata/keystore_geth_standard.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := Main(Request{Keystore: string(raw), KeystorePassword: vectorPassword})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Equal(t, "cannot decrypt keystore: scrypt n=262144 r=8 needs 256MB, above the function's memory limit of 64MB", resp.Body.Error)
+}
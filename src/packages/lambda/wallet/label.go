@@ -0,0 +1,87 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+)
+
+const (
+	labelDomain = "complete-mnemonic/label/v1"
+
+	InsecureLabelWarning = "INSECURE: this mnemonic is derived from the label, anyone who knows the label has its keys. Use it for test fixtures only!"
+)
+
+var (
+	// insecureLabels enables deterministic mnemonics from Request.Label. It is
+	// set only by tests or by builds with the "insecure" tag, the deployed
+	// function rejects the label.
+	insecureLabels = false
+
+	errLabelDisabled = errors.New("label is available only in insecure test builds, it makes the mnemonic guessable")
+	errLabelConflict = errors.New("label generates the mnemonic, don't combine it with mnemonic, phrase or entropy")
+)
+
+// validateLabel checks the label may be used for the request.
+func validateLabel(in Request) error {
+	if !insecureLabels {
+		return errLabelDisabled
+	}
+	if in.Mnemonic != "" || in.Phrase != "" || in.Entropy != "" {
+		return errLabelConflict
+	}
+	return hasCorrectWordsLength(in.Length)
+}
+
+// labelEntropy fills entropy from HMAC_DRBG (NIST SP 800-90A) seeded with the
+// label only, so the same label always yields the same mnemonic. This is NOT
+// random and must never protect real funds.
+func labelEntropy(label string, entropy []byte) {
+	drbg := newHMACDRBG([]byte(label), []byte(labelDomain))
+	drbg.Generate(entropy)
+}
+
+// hmacDRBG is HMAC_DRBG with SHA-256, without reseeding and prediction
+// resistance, which the deterministic use doesn't need.
+type hmacDRBG struct {
+	k, v []byte
+}
+
+func newHMACDRBG(entropy, nonce []byte) *hmacDRBG {
+	d := &hmacDRBG{
+		k: make([]byte, sha256.Size),
+		v: make([]byte, sha256.Size),
+	}
+	for i := range d.v {
+		d.v[i] = 0x01
+	}
+	d.update(append(append([]byte{}, entropy...), nonce...))
+	return d
+}
+
+func (d *hmacDRBG) mac(data ...[]byte) []byte {
+	h := hmac.New(sha256.New, d.k)
+	for _, b := range data {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+func (d *hmacDRBG) update(provided []byte) {
+	d.k = d.mac(d.v, []byte{0x00}, provided)
+	d.v = d.mac(d.v)
+	if len(provided) == 0 {
+		return
+	}
+	d.k = d.mac(d.v, []byte{0x01}, provided)
+	d.v = d.mac(d.v)
+}
+
+// Generate fills out with the next pseudorandom bytes.
+func (d *hmacDRBG) Generate(out []byte) {
+	for n := 0; n < len(out); {
+		d.v = d.mac(d.v)
+		n += copy(out[n:], d.v)
+	}
+	d.update(nil)
+}
@@ -0,0 +1,9 @@
+//go:build insecure
+
+package main
+
+// Builds with the "insecure" tag accept Request.Label, for local fixture
+// servers only. The deployed function is built without it.
+func init() {
+	insecureLabels = true
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func enableInsecureLabels(t *testing.T) {
+	t.Helper()
+	enabled := insecureLabels
+	insecureLabels = true
+	t.Cleanup(func() { insecureLabels = enabled })
+}
+
+func TestLabelDisabledByDefault(t *testing.T) {
+	if insecureLabels {
+		t.Skip("labels are enabled by the insecure build tag")
+	}
+	resp, err := Main(Request{Label: "alice"})
+	require.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+	assert.Equal(t, errLabelDisabled.Error(), resp.Body.Error)
+	assert.Empty(t, resp.Body.Wallet.Mnemonic)
+}
+
+func TestLabelMnemonic(t *testing.T) {
+	enableInsecureLabels(t)
+
+	tests := map[string]struct {
+		req          Request
+		expectedCode int
+	}{
+		"12 words":     {req: Request{Label: "alice", Count: 1}, expectedCode: 200},
+		"24 words":     {req: Request{Label: "alice", Length: 24, Count: 1}, expectedCode: 200},
+		"with phrase":  {req: Request{Label: "alice", Phrase: "test_junk"}, expectedCode: 400},
+		"with entropy": {req: Request{Label: "alice", Entropy: "7f"}, expectedCode: 400},
+		"wrong length": {req: Request{Label: "alice", Length: 13}, expectedCode: 400},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			first, err := Main(test.req)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCode, first.StatusCode, first.Body.Error)
+			if test.expectedCode != 200 {
+				return
+			}
+			second, err := Main(test.req)
+			require.NoError(t, err)
+			assert.Equal(t, first.Body.Wallet.Mnemonic, second.Body.Wallet.Mnemonic)
+			assert.Equal(t, first.Body.Accounts, second.Body.Accounts)
+			assert.Len(t, strings.Fields(first.Body.Wallet.Mnemonic), first.Body.Wallet.Length)
+			assert.Equal(t, InsecureLabelWarning, first.Body.Warning)
+		})
+	}
+}
+
+func TestLabelsDiffer(t *testing.T) {
+	enableInsecureLabels(t)
+
+	alice, err := Main(Request{Label: "alice", Count: 1})
+	require.NoError(t, err)
+	bob, err := Main(Request{Label: "bob", Count: 1})
+	require.NoError(t, err)
+	assert.NotEqual(t, alice.Body.Wallet.Mnemonic, bob.Body.Wallet.Mnemonic)
+}
+
+func TestHMACDRBGStream(t *testing.T) {
+	// one long read equals the first block of separate reads only, each
+	// Generate call updates the state
+	long := make([]byte, 64)
+	newHMACDRBG([]byte("alice"), []byte(labelDomain)).Generate(long)
+
+	d := newHMACDRBG([]byte("alice"), []byte(labelDomain))
+	first, second := make([]byte, 32), make([]byte, 32)
+	d.Generate(first)
+	d.Generate(second)
+	assert.Equal(t, long[:32], first)
+	assert.NotEqual(t, long[32:], second)
+}
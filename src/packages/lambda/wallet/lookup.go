@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// derivationPresets are path layouts used by popular wallets. Each preset
+// expands an account and an address index into a full derivation path, with
+// the account and the index the path actually holds.
+var derivationPresets = map[string]func(account, index int) (string, int, int){
+	"bip44": func(account, index int) (string, int, int) {
+		return fmt.Sprintf("m/44'/60'/%d'/0/%d", account, index), account, index
+	},
+	// Ledger Live has an address per account, the index enumerates accounts
+	"ledger-live": func(_, index int) (string, int, int) {
+		return fmt.Sprintf("m/44'/60'/%d'/0/0", index), index, 0
+	},
+	"ledger-legacy": func(account, index int) (string, int, int) {
+		return fmt.Sprintf("m/44'/60'/%d'/%d", account, index), account, index
+	},
+}
+
+type candidatePath struct {
+	preset  string
+	account int
+	index   int
+	path    string
+}
+
+func lookupAddress(in Request) *Response {
+	if !common.IsHexAddress(in.Address) {
+		return errorResponse(http.StatusBadRequest, fmt.Errorf("invalid address '%s'", in.Address))
+	}
+	presets, err := selectPresets(in.Presets)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	if err := validateLookupLimits(in.Limit, in.Accounts, len(presets)); err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+
+	wallet, err := newHDWallet(in.Mnemonic, in.Password, in.Strict)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, err)
+	}
+
+	target := common.HexToAddress(in.Address)
+	candidates := candidatePaths(in.Derivation, presets, in.Accounts, in.Limit)
+	for i, c := range candidates {
+		path, err := accounts.ParseDerivationPath(c.path)
+		if err != nil {
+			return errorResponse(http.StatusBadRequest, err)
+		}
+		account, err := wallet.Derive(path, false)
+		if err != nil {
+			return errorResponse(http.StatusInternalServerError, err)
+		}
+		if account.Address != target {
+			continue
+		}
+
+		return &Response{
+			StatusCode: http.StatusOK,
+			Body: ResponseBody{
+				Wallet: WalletBody{
+					Mnemonic:   in.Mnemonic,
+					Derivation: in.Derivation,
+					Length:     in.Length,
+				},
+				Match: &MatchBody{
+					Address: account.Address.Hex(),
+					Path:    c.path,
+					Preset:  c.preset,
+					Account: c.account,
+					Index:   c.index,
+					Tried:   i + 1,
+				},
+			},
+		}
+	}
+
+	return errorResponse(http.StatusNotFound,
+		fmt.Errorf("address %s not found within %d derivation paths", target.Hex(), len(candidates)))
+}
+
+// candidatePaths lists paths in the search order: the request derivation first,
+// then each of the presets, account by account. Duplicated paths are skipped.
+func candidatePaths(derivation string, presets []string, accountCount, limit int) []candidatePath {
+	seen := map[string]bool{}
+	paths := []candidatePath{}
+	add := func(c candidatePath) {
+		if !seen[c.path] {
+			seen[c.path] = true
+			paths = append(paths, c)
+		}
+	}
+
+	for i := 0; i < limit; i++ {
+		add(candidatePath{index: i, path: fmt.Sprintf("%s%d", derivation, i)})
+	}
+	for _, name := range presets {
+		for a := 0; a < accountCount; a++ {
+			for i := 0; i < limit; i++ {
+				path, account, index := derivationPresets[name](a, i)
+				add(candidatePath{preset: name, account: account, index: index, path: path})
+			}
+		}
+	}
+	return paths
+}
+
+// validateLookupLimits bounds the number of candidate paths, each takes a key
+// derivation.
+func validateLookupLimits(limit, accountCount, presetCount int) error {
+	if limit < 1 || limit > MaxLookupLimit {
+		return fmt.Errorf("limit must be between 1 and %d, got %d", MaxLookupLimit, limit)
+	}
+	if accountCount < 1 || accountCount > MaxLookupAccounts {
+		return fmt.Errorf("accounts must be between 1 and %d, got %d", MaxLookupAccounts, accountCount)
+	}
+	if paths := limit * (1 + accountCount*presetCount); paths > MaxLookupPaths {
+		return fmt.Errorf("limit %d with %d accounts of %d presets is %d derivation paths, at most %d allowed",
+			limit, accountCount, presetCount, paths, MaxLookupPaths)
+	}
+	return nil
+}
+
+func selectPresets(names string) ([]string, error) {
+	if names == "" {
+		return []string{}, nil
+	}
+	if names == "all" {
+		all := make([]string, 0, len(derivationPresets))
+		for name := range derivationPresets {
+			all = append(all, name)
+		}
+		sort.Strings(all)
+		return all, nil
+	}
+
+	presets := strings.Split(strings.ReplaceAll(names, "_", ","), ",")
+	for _, name := range presets {
+		if _, ok := derivationPresets[name]; !ok {
+			return nil, fmt.Errorf("unknown derivation preset '%s'", name)
+		}
+	}
+	return presets, nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressLookup(t *testing.T) {
+	tests := map[string]struct {
+		req           *Request
+		expectedCode  int
+		expectedMatch *MatchBody
+	}{
+		"index of default derivation": {
+			req: &Request{
+				Phrase:  "test junk",
+				Address: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+			},
+			expectedCode: 200,
+			expectedMatch: &MatchBody{
+				Address: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+				Path:    "m/44'/60'/0'/0/2",
+				Index:   2,
+				Tried:   3,
+			},
+		},
+		"lowercase address": {
+			req: &Request{
+				Phrase:  "test junk",
+				Address: "0x70997970c51812dc3a010c7d01b50e0d17dc79c8",
+			},
+			expectedCode: 200,
+			expectedMatch: &MatchBody{
+				Address: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+				Path:    "m/44'/60'/0'/0/1",
+				Index:   1,
+				Tried:   2,
+			},
+		},
+		"ledger live preset": {
+			req: &Request{
+				Phrase:  "test junk",
+				Address: "0x8C8d35429F74ec245F8Ef2f4Fd1e551cFF97d650",
+				Presets: "ledger-live",
+				Limit:   5,
+			},
+			expectedCode: 200,
+			expectedMatch: &MatchBody{
+				Address: "0x8C8d35429F74ec245F8Ef2f4Fd1e551cFF97d650",
+				Path:    "m/44'/60'/1'/0/0",
+				Preset:  "ledger-live",
+				Account: 1,
+				Tried:   6,
+			},
+		},
+		"all presets": {
+			req: &Request{
+				Phrase:  "test junk",
+				Address: "0x318b469BBa396AEc2C60342F9441be36A1945174",
+				Presets: "all",
+				Limit:   5,
+			},
+			expectedCode: 200,
+			expectedMatch: &MatchBody{
+				Address: "0x318b469BBa396AEc2C60342F9441be36A1945174",
+				Path:    "m/44'/60'/0'/2",
+				Preset:  "ledger-legacy",
+				Index:   2,
+				Tried:   8,
+			},
+		},
+		"not found within limit": {
+			req: &Request{
+				Phrase:  "test junk",
+				Address: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+				Limit:   2,
+			},
+			expectedCode: 404,
+		},
+		"invalid address": {
+			req: &Request{
+				Phrase:  "test junk",
+				Address: "0x3C44",
+			},
+			expectedCode: 400,
+		},
+		"negative limit": {
+			req: &Request{
+				Phrase:  "test junk",
+				Address: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+				Limit:   -1,
+			},
+			expectedCode: 400,
+		},
+		"limit above cap": {
+			req: &Request{
+				Phrase:  "test junk",
+				Address: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+				Limit:   MaxLookupLimit + 1,
+			},
+			expectedCode: 400,
+		},
+		"accounts above cap": {
+			req: &Request{
+				Phrase:   "test junk",
+				Address:  "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+				Presets:  "bip44",
+				Accounts: MaxLookupAccounts + 1,
+			},
+			expectedCode: 400,
+		},
+		"too many paths": {
+			req: &Request{
+				Phrase:   "test junk",
+				Address:  "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+				Presets:  "all",
+				Accounts: MaxLookupAccounts,
+				Limit:    MaxLookupLimit,
+			},
+			expectedCode: 400,
+		},
+		"unknown preset": {
+			req: &Request{
+				Phrase:  "test junk",
+				Address: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+				Presets: "trezor",
+			},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpLookup
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			assert.Equal(t, test.expectedMatch, resp.Body.Match)
+		})
+	}
+}
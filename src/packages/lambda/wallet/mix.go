@@ -0,0 +1,114 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+const (
+	mixDomain   = "complete-mnemonic/mix/v1"
+	mixCombiner = "sha256(\"" + mixDomain + "\" || systemEntropy || userHash), truncated to the entropy length"
+)
+
+// mixSymbols are accepted symbols of the caller's entropy by format, with the
+// bits each supplies.
+var mixSymbols = map[string]struct {
+	symbols string
+	bits    float64
+}{
+	EntropyHex:    {"0123456789abcdef", 4},
+	EntropyBinary: {"01", 1},
+	EntropyCoins:  {"ht01", 1},
+	EntropyDice:   {"123456", math.Log2(6)},
+}
+
+// MixBody is the transcript of mixing the caller's entropy into system
+// randomness. Anyone can recompute the entropy from it, changing either input
+// changes the mnemonic.
+type MixBody struct {
+	Combiner      string `json:"combiner"`
+	SystemEntropy string `json:"systemEntropy"`
+	UserFormat    string `json:"userFormat"`
+	UserInput     string `json:"userInput"`
+	UserHash      string `json:"userHash"`
+	UserBits      int    `json:"userBits"`
+	Entropy       string `json:"entropy"`
+}
+
+// mixedMnemonic generates the mnemonic from crypto/rand mixed with the caller's
+// entropy by a hash combiner. The result is as random as the better of the
+// sources, so a weak RNG or weak dice alone don't determine it.
+func mixedMnemonic(in Request) (string, *MixBody, error) {
+	if err := hasCorrectWordsLength(in.Length); err != nil {
+		return "", nil, err
+	}
+	input, bits, err := normalizeUserEntropy(in.EntropyFormat, in.Entropy)
+	if err != nil {
+		return "", nil, err
+	}
+
+	system := make([]byte, (in.Length*11-in.Length/3)/8)
+	if _, err := rand.Read(system); err != nil {
+		return "", nil, err
+	}
+	userHash := sha256.Sum256([]byte(input))
+	entropy := mixEntropy(system, userHash[:])
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", nil, err
+	}
+	return mnemonic, &MixBody{
+		Combiner:      mixCombiner,
+		SystemEntropy: hex.EncodeToString(system),
+		UserFormat:    in.EntropyFormat,
+		UserInput:     input,
+		UserHash:      hex.EncodeToString(userHash[:]),
+		UserBits:      bits,
+		Entropy:       hex.EncodeToString(entropy),
+	}, nil
+}
+
+// mixEntropy combines the system entropy with the hash of the caller's input,
+// the result has the length of the system entropy.
+func mixEntropy(system, userHash []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(mixDomain))
+	h.Write(system)
+	h.Write(userHash)
+	return h.Sum(nil)[:len(system)]
+}
+
+// normalizeUserEntropy validates the caller's input of any length and returns it
+// lowercase without separators, with the estimate of bits it supplies.
+func normalizeUserEntropy(format, input string) (string, int, error) {
+	accepted, ok := mixSymbols[format]
+	if !ok {
+		return "", 0, fmt.Errorf("invalid entropy format '%s', accepted values: %s, %s, %s, %s",
+			format, EntropyHex, EntropyBinary, EntropyDice, EntropyCoins)
+	}
+	input = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '_' || r == ',' || r == '\n' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(input))
+	if format == EntropyHex {
+		input = strings.TrimPrefix(input, "0x")
+	}
+	if input == "" {
+		return "", 0, fmt.Errorf("no %s symbols in the entropy", format)
+	}
+	for i, c := range input {
+		if !strings.ContainsRune(accepted.symbols, c) {
+			return "", 0, fmt.Errorf("invalid %s symbol '%c' at position %d", format, c, i)
+		}
+	}
+	return input, int(float64(len(input)) * accepted.bits), nil
+}
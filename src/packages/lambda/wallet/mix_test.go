@@ -0,0 +1,84 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+func TestMixedMnemonic(t *testing.T) {
+	tests := map[string]struct {
+		req               *Request
+		expectedCode      int
+		expectedLength    int
+		expectedUserInput string
+		expectedUserBits  int
+	}{
+		"dice": {
+			req:               &Request{Entropy: "1 6 2 5 3 4", EntropyFormat: EntropyDice},
+			expectedCode:      200,
+			expectedLength:    12,
+			expectedUserInput: "162534",
+			expectedUserBits:  15,
+		},
+		"hardware rng dump of 24 words": {
+			req:               &Request{Entropy: "0xDEADBEEF", Length: 24},
+			expectedCode:      200,
+			expectedLength:    24,
+			expectedUserInput: "deadbeef",
+			expectedUserBits:  32,
+		},
+		"invalid symbol": {
+			req:          &Request{Entropy: "1 7", EntropyFormat: EntropyDice},
+			expectedCode: 400,
+		},
+		"no symbols": {
+			req:          &Request{Entropy: "_", EntropyFormat: EntropyCoins},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.MixEntropy, test.req.Count = true, 1
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if resp.StatusCode != 200 {
+				return
+			}
+			mix := resp.Body.Mix
+			assert.Equal(t, test.expectedLength, resp.Body.Wallet.Length)
+			assert.Equal(t, test.expectedUserInput, mix.UserInput)
+			assert.Equal(t, test.expectedUserBits, mix.UserBits)
+			assert.Nil(t, resp.Body.Entropy)
+
+			// the transcript reproduces the mnemonic
+			system, _ := hex.DecodeString(mix.SystemEntropy)
+			userHash := sha256.Sum256([]byte(mix.UserInput))
+			assert.Equal(t, hex.EncodeToString(userHash[:]), mix.UserHash)
+			entropy := mixEntropy(system, userHash[:])
+			assert.Equal(t, hex.EncodeToString(entropy), mix.Entropy)
+			mnemonic, _ := bip39.NewMnemonic(entropy)
+			assert.Equal(t, mnemonic, resp.Body.Wallet.Mnemonic)
+
+			// neither source alone determines the result
+			again, _ := Main(*test.req)
+			assert.NotEqual(t, resp.Body.Wallet.Mnemonic, again.Body.Wallet.Mnemonic)
+			otherHash := sha256.Sum256([]byte(mix.UserInput + "1"))
+			assert.NotEqual(t, entropy, mixEntropy(system, otherHash[:]))
+		})
+	}
+}
+
+func TestMixCombinerDescribesMixEntropy(t *testing.T) {
+	system := make([]byte, 16)
+	userHash := sha256.Sum256([]byte("1234"))
+	expected := sha256.Sum256(append(append([]byte(mixDomain), system...), userHash[:]...))
+	assert.Equal(t, expected[:16], mixEntropy(system, userHash[:]))
+	assert.True(t, strings.Contains(mixCombiner, mixDomain))
+}
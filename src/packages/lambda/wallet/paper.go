@@ -0,0 +1,271 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const (
+	// A4 page in points.
+	paperWidth  = 595
+	paperHeight = 842
+	paperMargin = 40
+
+	paperColumns   = 3
+	paperCellH     = 26
+	paperQRSize    = 88
+	paperAccounts  = 4 // the page fits 4 accounts below 24 words
+	paperWatermark = 60
+
+	// watermarkEm is the width of PaperWatermark in Helvetica-Bold ems, to
+	// center it in the PDF.
+	watermarkEm = 11.836
+
+	PaperWatermark = "TEST ONLY – never fund"
+)
+
+type paperFont int
+
+const (
+	fontRegular paperFont = iota
+	fontBold
+	fontMono
+)
+
+// paperCanvas draws the page. Coordinates are points from the top left corner,
+// y of the text is its baseline.
+type paperCanvas interface {
+	Text(x, y, size float64, font paperFont, s string)
+	Rect(x, y, w, h float64, fill bool)
+	// Watermark draws the text across the page diagonal over the content.
+	Watermark(s string)
+}
+
+// paperSVG renders a printable backup card of the wallet as SVG.
+func paperSVG(in Request, accs []AccountBody, _ []*ecdsa.PrivateKey) (string, error) {
+	c := &svgCanvas{}
+	if err := drawPaperWallet(c, in, accs); err != nil {
+		return "", err
+	}
+	return c.Document(), nil
+}
+
+// paperPDF renders a printable backup card of the wallet as single page PDF.
+// The document is plain ASCII, so it fits the output string.
+func paperPDF(in Request, accs []AccountBody, _ []*ecdsa.PrivateKey) (string, error) {
+	c := &pdfCanvas{}
+	if err := drawPaperWallet(c, in, accs); err != nil {
+		return "", err
+	}
+	return c.Document(), nil
+}
+
+// drawPaperWallet lays out the mnemonic in a numbered grid with 4-letter
+// abbreviations, which identify BIP-39 words, and the first accounts with QR
+// codes of their addresses. The passphrase is never printed.
+func drawPaperWallet(c paperCanvas, in Request, accs []AccountBody) error {
+	x, y := float64(paperMargin), float64(paperMargin+20)
+	c.Text(x, y, 22, fontBold, "Paper wallet")
+	y += 22
+	c.Text(x, y, 14, fontBold, PaperWatermark)
+	y += 22
+	c.Text(x, y, 11, fontRegular, "Derivation path: "+in.Derivation)
+	y += 16
+	passphrase := "none"
+	if in.Password != "" {
+		passphrase = "required, not printed"
+	}
+	c.Text(x, y, 11, fontRegular, "BIP-39 passphrase: "+passphrase)
+
+	words := strings.Fields(in.Mnemonic)
+	y += 30
+	c.Text(x, y, 13, fontBold, fmt.Sprintf("Mnemonic, %d words", len(words)))
+	y += 10
+	cellW := float64(paperWidth-2*paperMargin) / paperColumns
+	rows := (len(words) + paperColumns - 1) / paperColumns
+	for i, word := range words {
+		// numbered down the columns as on backup cards
+		cx, cy := x+float64(i/rows)*cellW, y+float64(i%rows)*paperCellH
+		c.Rect(cx, cy, cellW, paperCellH, false)
+		c.Text(cx+6, cy+17, 9, fontRegular, fmt.Sprintf("%02d", i+1))
+		c.Text(cx+26, cy+18, 13, fontBold, abbreviate(word))
+		c.Text(cx+76, cy+17, 10, fontRegular, word)
+	}
+
+	y += float64(rows*paperCellH) + 34
+	c.Text(x, y, 13, fontBold, "Accounts")
+	y += 10
+	if len(accs) > paperAccounts {
+		accs = accs[:paperAccounts]
+	}
+	for i, acc := range accs {
+		q, err := qrcode.New(acc.Address, qrcode.Medium)
+		if err != nil {
+			return err
+		}
+		drawQR(c, q.Bitmap(), x, y, paperQRSize)
+		c.Text(x+paperQRSize+12, y+paperQRSize/2-6, 10, fontRegular, fmt.Sprintf("%s%d", in.Derivation, i))
+		c.Text(x+paperQRSize+12, y+paperQRSize/2+10, 10, fontMono, acc.Address)
+		y += paperQRSize + 8
+	}
+
+	c.Text(x, paperHeight-24, 8, fontRegular, "Generated offline from the wallet response. Whoever holds this card controls the accounts.")
+	c.Watermark(PaperWatermark)
+	return nil
+}
+
+// drawQR draws the dark module runs of each bitmap row, the bitmap includes the
+// quiet zone.
+func drawQR(c paperCanvas, bitmap [][]bool, x, y, size float64) {
+	module := size / float64(len(bitmap))
+	for row, modules := range bitmap {
+		for col := 0; col < len(modules); {
+			if !modules[col] {
+				col++
+				continue
+			}
+			run := 0
+			for col+run < len(modules) && modules[col+run] {
+				run++
+			}
+			c.Rect(x+float64(col)*module, y+float64(row)*module, float64(run)*module, module, true)
+			col += run
+		}
+	}
+}
+
+// abbreviate returns the first 4 letters of the word, unique in the BIP-39 list.
+func abbreviate(word string) string {
+	if len(word) > 4 {
+		word = word[:4]
+	}
+	return strings.ToUpper(word)
+}
+
+// watermarkAngle is the page diagonal from the bottom left corner.
+var watermarkAngle = math.Atan2(paperHeight, paperWidth)
+
+var svgFonts = map[paperFont]string{
+	fontRegular: `font-family="Helvetica, Arial, sans-serif"`,
+	fontBold:    `font-family="Helvetica, Arial, sans-serif" font-weight="bold"`,
+	fontMono:    `font-family="Courier, monospace"`,
+}
+
+var svgEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+type svgCanvas struct {
+	b strings.Builder
+}
+
+func (c *svgCanvas) Text(x, y, size float64, font paperFont, s string) {
+	fmt.Fprintf(&c.b, `<text x="%.2f" y="%.2f" font-size="%g" %s>%s</text>`+"\n", x, y, size, svgFonts[font], svgEscaper.Replace(s))
+}
+
+func (c *svgCanvas) Rect(x, y, w, h float64, fill bool) {
+	paint := `fill="none" stroke="#000" stroke-width="0.75"`
+	if fill {
+		paint = `fill="#000"`
+	}
+	fmt.Fprintf(&c.b, `<rect x="%.2f" y="%.2f" width="%.2f" height="%.2f" %s/>`+"\n", x, y, w, h, paint)
+}
+
+func (c *svgCanvas) Watermark(s string) {
+	cx, cy := float64(paperWidth)/2, float64(paperHeight)/2
+	fmt.Fprintf(&c.b, `<text x="%.2f" y="%.2f" font-size="%d" %s fill="#c00000" fill-opacity="0.3" text-anchor="middle" dominant-baseline="middle" transform="rotate(%.2f %.2f %.2f)">%s</text>`+"\n",
+		cx, cy, paperWatermark, svgFonts[fontBold], -watermarkAngle*180/math.Pi, cx, cy, svgEscaper.Replace(s))
+}
+
+func (c *svgCanvas) Document() string {
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="210mm" height="297mm" viewBox="0 0 %d %d">`+"\n"+
+		`<rect width="%d" height="%d" fill="#fff"/>`+"\n%s</svg>\n", paperWidth, paperHeight, paperWidth, paperHeight, c.b.String())
+}
+
+var pdfFonts = map[paperFont]string{
+	fontRegular: "F1",
+	fontBold:    "F2",
+	fontMono:    "F3",
+}
+
+type pdfCanvas struct {
+	b strings.Builder
+}
+
+func (c *pdfCanvas) Text(x, y, size float64, font paperFont, s string) {
+	fmt.Fprintf(&c.b, "BT /%s %g Tf %.2f %.2f Td (%s) Tj ET\n", pdfFonts[font], size, x, paperHeight-y, pdfString(s))
+}
+
+func (c *pdfCanvas) Rect(x, y, w, h float64, fill bool) {
+	paint := "S"
+	if fill {
+		paint = "f"
+	}
+	fmt.Fprintf(&c.b, "%.2f %.2f %.2f %.2f re %s\n", x, paperHeight-y-h, w, h, paint)
+}
+
+func (c *pdfCanvas) Watermark(s string) {
+	cos, sin := math.Cos(watermarkAngle), math.Sin(watermarkAngle)
+	// start of the baseline centering the text on the page
+	half, drop := watermarkEm*paperWatermark/2, 0.35*paperWatermark
+	x := float64(paperWidth)/2 - half*cos + drop*sin
+	y := float64(paperHeight)/2 - half*sin - drop*cos
+	fmt.Fprintf(&c.b, "q /GS1 gs 0.75 0 0 rg BT /F2 %d Tf %.4f %.4f %.4f %.4f %.2f %.2f Tm (%s) Tj ET Q\n",
+		paperWatermark, cos, sin, -sin, cos, x, y, pdfString(s))
+}
+
+// Document assembles the page content into the PDF file with its xref table.
+func (c *pdfCanvas) Document() string {
+	content := "0.75 w\n" + strings.TrimSuffix(c.b.String(), "\n")
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 4 0 R /F2 5 0 R /F3 6 0 R >> /ExtGState << /GS1 << /ca 0.3 >> >> >> /Contents 7 0 R >>", paperWidth, paperHeight),
+		pdfFont("Helvetica"),
+		pdfFont("Helvetica-Bold"),
+		pdfFont("Courier"),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	}
+
+	var b strings.Builder
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.String()
+}
+
+func pdfFont(name string) string {
+	return fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name)
+}
+
+// pdfString escapes the text for a PDF literal string. The en dash is written as
+// its WinAnsiEncoding code, other non-ASCII runes aren't printable by the
+// standard fonts.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == '–':
+			b.WriteString(`\226`)
+		case r < 0x20 || r > 0x7e:
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
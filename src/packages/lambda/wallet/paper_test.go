@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaperWallet(t *testing.T) {
+	tests := map[string]struct {
+		req              *Request
+		expectedAccounts int
+	}{
+		"svg":            {req: &Request{Format: FormatSVG, Count: 2}, expectedAccounts: 2},
+		"pdf":            {req: &Request{Format: FormatPDF, Count: 2}, expectedAccounts: 2},
+		"svg 24 words":   {req: &Request{Format: FormatSVG, Phrase: "abandon art", Length: 24}, expectedAccounts: paperAccounts},
+		"pdf passphrase": {req: &Request{Format: FormatPDF, Password: "secret"}, expectedAccounts: paperAccounts},
+	}
+
+	for name, test := range tests {
+		if test.req.Phrase == "" {
+			test.req.Phrase = "test junk"
+		}
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			require.NoError(t, err)
+			require.Equal(t, 200, resp.StatusCode, resp.Body.Error)
+
+			output, text := resp.Body.Output, "(%s)"
+			if test.req.Format == FormatSVG {
+				text = ">%s</text>"
+				assertWellFormedXML(t, output)
+				assert.Contains(t, output, PaperWatermark)
+			} else {
+				assertValidPDFXref(t, output)
+				assert.Contains(t, output, `TEST ONLY \226 never fund`)
+				for _, r := range output {
+					require.Less(t, r, rune(0x80), "PDF must be plain ASCII")
+				}
+			}
+			for i, word := range strings.Fields(resp.Body.Wallet.Mnemonic) {
+				assert.Contains(t, output, fmt.Sprintf(text, word))
+				assert.Contains(t, output, fmt.Sprintf(text, abbreviate(word)))
+				assert.Contains(t, output, fmt.Sprintf(text, fmt.Sprintf("%02d", i+1)))
+			}
+			for i, acc := range resp.Body.Accounts {
+				if i < test.expectedAccounts {
+					assert.Contains(t, output, fmt.Sprintf(text, acc.Address))
+				} else {
+					assert.NotContains(t, output, acc.Address)
+				}
+			}
+			assert.NotContains(t, output, "secret")
+		})
+	}
+}
+
+func TestAbbreviate(t *testing.T) {
+	assert.Equal(t, "ABAN", abbreviate("abandon"))
+	assert.Equal(t, "ZOO", abbreviate("zoo"))
+	assert.Equal(t, "TEST", abbreviate("test"))
+}
+
+func assertWellFormedXML(t *testing.T, doc string) {
+	t.Helper()
+	d := xml.NewDecoder(strings.NewReader(doc))
+	for {
+		_, err := d.Token()
+		if err == io.EOF {
+			return
+		}
+		require.NoError(t, err)
+	}
+}
+
+var pdfXrefEntry = regexp.MustCompile(`(\d{10}) 00000 n `)
+
+// assertValidPDFXref checks each xref entry points to its object and startxref
+// to the table.
+func assertValidPDFXref(t *testing.T, doc string) {
+	t.Helper()
+	require.True(t, strings.HasPrefix(doc, "%PDF-1.4\n"))
+	require.True(t, strings.HasSuffix(doc, "%%EOF\n"))
+
+	lines := strings.Split(strings.TrimSuffix(doc, "\n"), "\n")
+	xref, err := strconv.Atoi(lines[len(lines)-2])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(doc[xref:], "xref\n"))
+
+	for i, m := range pdfXrefEntry.FindAllStringSubmatch(doc[xref:], -1) {
+		offset, _ := strconv.Atoi(m[1])
+		assert.True(t, strings.HasPrefix(doc[offset:], fmt.Sprintf("%d 0 obj\n", i+1)), "object %d", i+1)
+	}
+
+	stream := regexp.MustCompile(`<< /Length (\d+) >>\nstream\n`).FindStringSubmatchIndex(doc)
+	require.NotNil(t, stream)
+	length, _ := strconv.Atoi(doc[stream[2]:stream[3]])
+	assert.True(t, strings.HasPrefix(doc[stream[1]+length:], "\nendstream"))
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Cost of a candidate on a single core: the seed takes about 1.2ms and each
+// path derivation 0.4ms. Searches longer than MaxRecoveryDuration are rejected
+// to respond within 3500ms function limit, the search stops at the deadline
+// anyway if the estimate falls short.
+const (
+	recoverySeedCost    = 1200 * time.Microsecond
+	recoveryPathCost    = 400 * time.Microsecond
+	MaxRecoveryDuration = 3000 * time.Millisecond
+	// MaxRecoveryCandidates is the number of lines checked within
+	// MaxRecoveryDuration at a single path.
+	MaxRecoveryCandidates = int(MaxRecoveryDuration / recoverySeedCost)
+)
+
+// passphraseRule mutates a candidate passphrase into at most fanOut variants.
+// Every variant list starts with the unchanged candidate.
+type passphraseRule struct {
+	fanOut   int
+	variants func(string) []string
+}
+
+var passphraseRules = map[string]passphraseRule{
+	"case":   {4, caseVariants},
+	"leet":   {2, leetVariants},
+	"digits": {111, digitVariants},
+}
+
+var leetReplacer = strings.NewReplacer("a", "4", "e", "3", "i", "1", "o", "0", "s", "5", "t", "7")
+
+func recoverPassphrase(in Request) *Response {
+	if !common.IsHexAddress(in.Address) {
+		return errorResponse(http.StatusBadRequest, fmt.Errorf("invalid address '%s'", in.Address))
+	}
+	lines, err := candidateLines(in.Candidates)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	rules, err := parseRules(in.Rules)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	// variants are estimated before they are expanded, memory of the expansion
+	// is bounded by the time limit
+	total := len(lines)
+	for _, rule := range rules {
+		total *= rule.fanOut
+	}
+	if err := validateRecoveryCost(total, in.Count); err != nil {
+		resp := errorResponse(http.StatusBadRequest, err)
+		resp.Body.Recovery = &RecoveryBody{Total: total}
+		return resp
+	}
+	candidates := expandCandidates(lines, rules)
+	paths := make([]accounts.DerivationPath, in.Count)
+	for i := range paths {
+		paths[i], err = accounts.ParseDerivationPath(fmt.Sprintf("%s%d", in.Derivation, i))
+		if err != nil {
+			return errorResponse(http.StatusBadRequest, err)
+		}
+	}
+
+	recovery := searchPassphrase(in.Mnemonic, in.Strict, common.HexToAddress(in.Address), paths, candidates)
+	resp := &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Wallet: WalletBody{
+				Mnemonic:   in.Mnemonic,
+				Derivation: in.Derivation,
+				Length:     in.Length,
+			},
+			Recovery: recovery,
+		},
+	}
+	if !recovery.Found {
+		resp.StatusCode = http.StatusNotFound
+		resp.Body.Error = fmt.Sprintf("none of %d passphrases derives %s", recovery.Tried, in.Address)
+	}
+	if recovery.TimedOut {
+		resp.Body.Error += fmt.Sprintf(", stopped after %v with %d of %d tried", MaxRecoveryDuration, recovery.Tried, recovery.Total)
+	}
+	return resp
+}
+
+// validateRecoveryCost rejects searches of total candidates at count paths each
+// which cannot finish within MaxRecoveryDuration.
+func validateRecoveryCost(total, count int) error {
+	if count < 1 {
+		return fmt.Errorf("count must be positive, got %d", count)
+	}
+	estimate := time.Duration(total) * (recoverySeedCost + time.Duration(count)*recoveryPathCost)
+	if int64(count) > int64(MaxRecoveryDuration/recoveryPathCost) || estimate > MaxRecoveryDuration {
+		return fmt.Errorf("%d passphrases at %d paths take about %v, above the %v limit, give fewer candidates, rules or count",
+			total, count, estimate.Round(time.Millisecond), MaxRecoveryDuration)
+	}
+	return nil
+}
+
+// searchPassphrase tries candidates in parallel and stops at the first one
+// deriving the target address at any of the paths, or at MaxRecoveryDuration.
+func searchPassphrase(mnemonic string, strict bool, target common.Address, paths []accounts.DerivationPath, candidates []string) *RecoveryBody {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), MaxRecoveryDuration)
+		queue       = make(chan string)
+		tried       atomic.Int64
+		once        sync.Once
+		wg          sync.WaitGroup
+		recovery    = &RecoveryBody{Total: len(candidates)}
+	)
+	defer cancel()
+
+	worker := func() {
+		defer wg.Done()
+		for passphrase := range queue {
+			path, ok := derivesAddress(mnemonic, passphrase, strict, target, paths)
+			tried.Add(1)
+			if ok {
+				once.Do(func() {
+					recovery.Found, recovery.Passphrase, recovery.Path = true, passphrase, path
+					cancel()
+				})
+			}
+		}
+	}
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go worker()
+	}
+
+feed:
+	for _, candidate := range candidates {
+		select {
+		case queue <- candidate:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	recovery.Tried = int(tried.Load())
+	recovery.TimedOut = !recovery.Found && ctx.Err() == context.DeadlineExceeded
+	return recovery
+}
+
+func derivesAddress(mnemonic, passphrase string, strict bool, target common.Address, paths []accounts.DerivationPath) (string, bool) {
+	wallet, err := newHDWallet(mnemonic, passphrase, strict)
+	if err != nil {
+		return "", false
+	}
+	for _, path := range paths {
+		account, err := wallet.Derive(path, false)
+		if err == nil && account.Address == target {
+			return path.String(), true
+		}
+	}
+	return "", false
+}
+
+// candidateLines returns the non-empty lines of the candidates list.
+func candidateLines(list string) ([]string, error) {
+	lines := []string{}
+	for _, line := range strings.Split(list, "\n") {
+		if line = strings.TrimRight(line, "\r"); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no candidate passphrases given")
+	}
+	if len(lines) > MaxRecoveryCandidates {
+		return nil, fmt.Errorf("%d candidate passphrases given, at most %d accepted", len(lines), MaxRecoveryCandidates)
+	}
+	return lines, nil
+}
+
+// parseRules returns the mutation rules of the comma or underscore separated
+// list, each rule may be given once.
+func parseRules(list string) ([]passphraseRule, error) {
+	rules := []passphraseRule{}
+	seen := map[string]bool{}
+	for _, name := range strings.FieldsFunc(list, func(r rune) bool { return r == ',' || r == '_' }) {
+		rule, ok := passphraseRules[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown mutation rule '%s', accepted values: case, leet, digits", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("mutation rule '%s' given more than once", name)
+		}
+		seen[name] = true
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// expandCandidates applies the rules, in the given order, to every line of
+// the candidates list. Duplicated variants are tried only once.
+func expandCandidates(lines []string, rules []passphraseRule) []string {
+	variants := lines
+	for _, rule := range rules {
+		mutated := make([]string, 0, len(variants)*rule.fanOut)
+		for _, v := range variants {
+			mutated = append(mutated, rule.variants(v)...)
+		}
+		variants = mutated
+	}
+
+	seen := map[string]bool{}
+	unique := []string{}
+	for _, v := range variants {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
+
+func caseVariants(s string) []string {
+	first, size := utf8.DecodeRuneInString(s)
+	title := string(unicode.ToUpper(first)) + strings.ToLower(s[size:])
+	return []string{s, strings.ToLower(s), strings.ToUpper(s), title}
+}
+
+func leetVariants(s string) []string {
+	return []string{s, leetReplacer.Replace(strings.ToLower(s))}
+}
+
+func digitVariants(s string) []string {
+	variants := []string{s}
+	for i := 0; i < 10; i++ {
+		variants = append(variants, fmt.Sprintf("%s%d", s, i))
+	}
+	for i := 0; i < 100; i++ {
+		variants = append(variants, fmt.Sprintf("%s%02d", s, i))
+	}
+	return variants
+}
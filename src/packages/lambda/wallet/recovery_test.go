@@ -0,0 +1,149 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPassphraseRecovery(t *testing.T) {
+	tests := map[string]struct {
+		req              *Request
+		expectedCode     int
+		expectedRecovery *RecoveryBody
+	}{
+		"exact candidate": {
+			req: &Request{
+				Candidates: "letmein\npassword\nsecret",
+				Address:    "0xb1a3B55051E04d44Ce457A6A479c999557521921",
+			},
+			expectedCode: 200,
+			expectedRecovery: &RecoveryBody{
+				Found:      true,
+				Passphrase: "password",
+				Path:       "m/44'/60'/0'/0/1",
+				Total:      3,
+			},
+		},
+		"mutated candidate": {
+			req: &Request{
+				Candidates: "PassWord",
+				Rules:      "case,digits",
+				Address:    "0xfaFfA9053ac6c6315Aa7806d1336F10F9b280Ee9",
+				Count:      1,
+			},
+			expectedCode: 200,
+			expectedRecovery: &RecoveryBody{
+				Found:      true,
+				Passphrase: "password",
+				Path:       "m/44'/60'/0'/0/0",
+				Total:      444,
+			},
+		},
+		"not found": {
+			req: &Request{
+				Candidates: "Password\npassw0rd",
+				Address:    "0xfaFfA9053ac6c6315Aa7806d1336F10F9b280Ee9",
+				Count:      1,
+			},
+			expectedCode: 404,
+			expectedRecovery: &RecoveryBody{
+				Tried: 2,
+				Total: 2,
+			},
+		},
+		"too many candidates": {
+			req: &Request{
+				Candidates: "password\nletmein",
+				Rules:      "case,leet,digits",
+				Address:    "0xfaFfA9053ac6c6315Aa7806d1336F10F9b280Ee9",
+			},
+			expectedCode: 400,
+			expectedRecovery: &RecoveryBody{
+				Total: 1776,
+			},
+		},
+		"repeated rule": {
+			req: &Request{
+				Candidates: "password",
+				Rules:      "digits,digits,digits,digits",
+				Address:    "0xfaFfA9053ac6c6315Aa7806d1336F10F9b280Ee9",
+			},
+			expectedCode: 400,
+		},
+		"negative count": {
+			req: &Request{
+				Candidates: "password",
+				Address:    "0xfaFfA9053ac6c6315Aa7806d1336F10F9b280Ee9",
+				Count:      -1,
+			},
+			expectedCode: 400,
+			expectedRecovery: &RecoveryBody{
+				Total: 1,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpRecoverPassphrase
+		test.req.Phrase = "test junk"
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if test.expectedRecovery == nil {
+				assert.Nil(t, resp.Body.Recovery)
+				return
+			}
+			if test.expectedRecovery.Found {
+				// parallel workers may try a few more candidates before they stop
+				assert.LessOrEqual(t, 1, resp.Body.Recovery.Tried)
+				test.expectedRecovery.Tried = resp.Body.Recovery.Tried
+			}
+			assert.Equal(t, test.expectedRecovery, resp.Body.Recovery)
+		})
+	}
+}
+
+func TestPassphraseMutationRules(t *testing.T) {
+	tests := map[string]struct {
+		candidates string
+		rules      string
+		expected   []string
+	}{
+		"no rules": {
+			candidates: "one\ntwo\r\n\none",
+			expected:   []string{"one", "two"},
+		},
+		"case": {
+			candidates: "hElLo",
+			rules:      "case",
+			expected:   []string{"hElLo", "hello", "HELLO", "Hello"},
+		},
+		"leet after case": {
+			candidates: "Test",
+			rules:      "case_leet",
+			expected:   []string{"Test", "7357", "test", "TEST"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			lines, err := candidateLines(test.candidates)
+			assert.NoError(t, err)
+			rules, err := parseRules(test.rules)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, expandCandidates(lines, rules))
+		})
+	}
+
+	_, err := parseRules("reverse")
+	assert.EqualError(t, err, "unknown mutation rule 'reverse', accepted values: case, leet, digits")
+	_, err = parseRules("digits_case_digits")
+	assert.EqualError(t, err, "mutation rule 'digits' given more than once")
+	_, err = candidateLines(strings.Repeat("secret\n", MaxRecoveryCandidates+1))
+	assert.EqualError(t, err, "2501 candidate passphrases given, at most 2500 accepted")
+}
@@ -2,9 +2,11 @@ package main
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
 
 	bip39 "github.com/tyler-smith/go-bip39"
+	"golang.org/x/text/unicode/norm"
 )
 
 func Repeat(phrase string, length int) (string, error) {
@@ -28,6 +30,22 @@ func Repeat(phrase string, length int) (string, error) {
 	return strings.Join(dst, " "), nil
 }
 
+// completeChecksum replaces checksum bits of the last word, so the words make
+// a valid mnemonic.
+func completeChecksum(words []string) (string, error) {
+	bits := new(big.Int)
+	for _, word := range words {
+		index, ok := bip39.GetWordIndex(word)
+		if !ok {
+			return "", fmt.Errorf("word '%s' is not in WordList", word)
+		}
+		bits.Lsh(bits, 11).Or(bits, big.NewInt(int64(index)))
+	}
+	bits.Rsh(bits, uint(len(words)/3))
+	entropy := bits.FillBytes(make([]byte, len(words)*4/3))
+	return bip39.NewMnemonic(entropy)
+}
+
 func hasCorrectWordsLength(length int) error {
 	if !(length%3 == 0 && length >= 12 && length <= 24) {
 		return fmt.Errorf("invalid length of '%d', accepted values: 12, 15, 18, 21, 24", length)
@@ -36,7 +54,7 @@ func hasCorrectWordsLength(length int) error {
 }
 
 func toWordList(phrase string) ([]string, error) {
-	phrase = strings.ReplaceAll(phrase, "_", " ")
+	phrase = norm.NFKD.String(strings.ReplaceAll(phrase, "_", " "))
 	words := strings.Fields(phrase)
 	if len(words) == 0 {
 		return []string{}, fmt.Errorf("no words found in '%s'", phrase)
@@ -1,20 +1,134 @@
 package main
 
+import "encoding/json"
+
 const (
 	DefaultPhraseLength = 12
 	DefaultDerivation   = "m/44'/60'/0'/0/"
 	DefaultAccountCount = 10
+	DefaultLookupLimit  = 100
+	MaxLookupLimit      = 1000
+	MaxLookupAccounts   = 20
+	// MaxLookupPaths derive within 3500ms function limit, about 0.5ms each.
+	MaxLookupPaths = 5000
+
+	OpLookup            = "lookup"
+	OpRecoverPassphrase = "recover-passphrase"
+	OpSign              = "sign"
+	OpRecoverSigner     = "recover"
+	OpVerify            = "verify"
+	OpSignTypedData     = "sign-typed-data"
+	OpSignTransaction   = "sign-tx"
+	OpVanity            = "vanity"
+	OpAnalyze           = "analyze"
+	OpBIP85             = "bip85"
+
+	TxLegacy     = "legacy"
+	TxAccessList = "eip2930"
+	TxDynamicFee = "eip1559"
+
+	ExportKeystore = "keystore"
+	KDFScrypt      = "scrypt"
+	KDFPBKDF2      = "pbkdf2"
+
+	FormatGeth    = "geth"
+	FormatAnvil   = "anvil"
+	FormatHardhat = "hardhat"
+	FormatFoundry = "foundry"
+	FormatEnv     = "env"
+	FormatCSV     = "csv"
+	FormatSVG     = "svg"
+	FormatPDF     = "pdf"
+
+	DefaultBalance        = "10000000000000000000000"
+	DefaultGenesisChainID = 1337
+
+	FamilySingle = "single"
+	FamilyLucky  = "lucky"
+	FamilyPairs  = "pairs"
+
+	DefaultVanityTimeout = 2500
+	// MaxVanityTimeout leaves time to respond within 3500ms function limit.
+	MaxVanityTimeout = 3000
+	MaxVanityCount   = 20
+
+	AppBIP39    = "bip39"
+	AppHex      = "hex"
+	AppWIF      = "wif"
+	AppPassword = "pwd-base64"
+
+	EntropyHex    = "hex"
+	EntropyBinary = "binary"
+	EntropyDice   = "dice"
+	EntropyCoins  = "coins"
+
+	DefaultLanguage       = "english"
+	DefaultHexBytes       = 32
+	DefaultPasswordLength = 20
 )
 
 // Request is the function's request struct
 type Request struct {
-	Length        int    `json:"length,string,omitempty"`
-	Count         int    `json:"count,string,omitempty"`
-	Mnemonic      string `json:"mnemonic,omitempty"`
-	Phrase        string `json:"phrase,omitempty"`
-	Derivation    string `json:"derivation,omitempty"`
-	Password      string `json:"password,omitempty"`
-	RevealPrivate bool   `json:"reveal,string,omitempty"`
+	Length           int             `json:"length,string,omitempty"`
+	Count            int             `json:"count,string,omitempty"`
+	Mnemonic         string          `json:"mnemonic,omitempty"`
+	Phrase           string          `json:"phrase,omitempty"`
+	Derivation       string          `json:"derivation,omitempty"`
+	Password         string          `json:"password,omitempty"`
+	RevealPrivate    bool            `json:"reveal,string,omitempty"`
+	Op               string          `json:"op,omitempty"`
+	Address          string          `json:"address,omitempty"`
+	Limit            int             `json:"limit,string,omitempty"`
+	Accounts         int             `json:"accounts,string,omitempty"`
+	Presets          string          `json:"presets,omitempty"`
+	Candidates       string          `json:"candidates,omitempty"`
+	Rules            string          `json:"rules,omitempty"`
+	Export           string          `json:"export,omitempty"`
+	KeystorePassword string          `json:"keystorePassword,omitempty"`
+	KDF              string          `json:"kdf,omitempty"`
+	Keystore         string          `json:"keystore,omitempty"`
+	NewPassword      string          `json:"newKeystorePassword,omitempty"`
+	Message          string          `json:"message,omitempty"`
+	Index            int             `json:"index,string,omitempty"`
+	Signature        string          `json:"signature,omitempty"`
+	TypedData        json.RawMessage `json:"typedData,omitempty"`
+
+	ChainID              int64           `json:"chainId,string,omitempty"`
+	TxType               string          `json:"txType,omitempty"`
+	Nonce                uint64          `json:"nonce,string,omitempty"`
+	Gas                  uint64          `json:"gas,string,omitempty"`
+	GasPrice             string          `json:"gasPrice,omitempty"`
+	MaxFeePerGas         string          `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string          `json:"maxPriorityFeePerGas,omitempty"`
+	To                   string          `json:"to,omitempty"`
+	Value                string          `json:"value,omitempty"`
+	Data                 string          `json:"data,omitempty"`
+	AccessList           json.RawMessage `json:"accessList,omitempty"`
+
+	Format  string `json:"format,omitempty"`
+	Balance string `json:"balance,omitempty"`
+	Fork    string `json:"fork,omitempty"`
+
+	Family  string `json:"family,omitempty"`
+	Prefix  string `json:"prefix,omitempty"`
+	Suffix  string `json:"suffix,omitempty"`
+	Pattern string `json:"pattern,omitempty"`
+	Timeout int    `json:"timeout,string,omitempty"`
+
+	App      string `json:"app,omitempty"`
+	Language string `json:"language,omitempty"`
+	Words    int    `json:"words,string,omitempty"`
+	Size     int    `json:"size,string,omitempty"`
+
+	Entropy       string `json:"entropy,omitempty"`
+	EntropyFormat string `json:"entropyFormat,omitempty"`
+	MixEntropy    bool   `json:"mix,string,omitempty"`
+
+	// Strict derives keys as BIP-32 specifies, see walletFromSeed.
+	Strict bool `json:"strict,string,omitempty"`
+
+	// Label makes the random mnemonic deterministic, insecure test builds only.
+	Label string `json:"label,omitempty"`
 }
 
 // Response is the function's response struct
@@ -25,9 +139,20 @@ type Response struct {
 }
 
 type ResponseBody struct {
-	Wallet   WalletBody    `json:"wallet"`
-	Accounts []AccountBody `json:"accounts"`
-	Error    string        `json:"error,omitempty"`
+	Wallet      WalletBody       `json:"wallet"`
+	Accounts    []AccountBody    `json:"accounts"`
+	Match       *MatchBody       `json:"match,omitempty"`
+	Recovery    *RecoveryBody    `json:"recovery,omitempty"`
+	Signature   *SignatureBody   `json:"signature,omitempty"`
+	Transaction *TransactionBody `json:"transaction,omitempty"`
+	Output      string           `json:"output,omitempty"`
+	Vanity      *VanityBody      `json:"vanity,omitempty"`
+	Analysis    *AnalysisBody    `json:"analysis,omitempty"`
+	BIP85       *BIP85Body       `json:"bip85,omitempty"`
+	Entropy     *EntropyBody     `json:"entropy,omitempty"`
+	Mix         *MixBody         `json:"mix,omitempty"`
+	Warning     string           `json:"warning,omitempty"`
+	Error       string           `json:"error,omitempty"`
 }
 
 type WalletBody struct {
@@ -37,9 +162,105 @@ type WalletBody struct {
 }
 
 type AccountBody struct {
-	Address    string `json:"address"`
-	PublicKey  string `json:"publicKey,omitempty"`
-	PrivateKey string `json:"privateKey,omitempty"`
+	Address    string          `json:"address"`
+	PublicKey  string          `json:"publicKey,omitempty"`
+	PrivateKey string          `json:"privateKey,omitempty"`
+	Keystore   json.RawMessage `json:"keystore,omitempty"`
+}
+
+// MatchBody describes where the looked up address was found
+type MatchBody struct {
+	Address string `json:"address"`
+	Path    string `json:"path"`
+	Preset  string `json:"preset,omitempty"`
+	Account int    `json:"account"`
+	Index   int    `json:"index"`
+	Tried   int    `json:"tried"`
+}
+
+// RecoveryBody reports the progress and the outcome of a passphrase recovery
+type RecoveryBody struct {
+	Found      bool   `json:"found"`
+	Passphrase string `json:"passphrase,omitempty"`
+	Path       string `json:"path,omitempty"`
+	Tried      int    `json:"tried"`
+	Total      int    `json:"total"`
+	TimedOut   bool   `json:"timedOut"`
+}
+
+// SignatureBody holds the signature split into its r, s and v values
+type SignatureBody struct {
+	Address   string `json:"address"`
+	Path      string `json:"path,omitempty"`
+	Hash      string `json:"hash"`
+	R         string `json:"r,omitempty"`
+	S         string `json:"s,omitempty"`
+	V         int    `json:"v,omitempty"`
+	Signature string `json:"signature,omitempty"`
+	Valid     *bool  `json:"valid,omitempty"`
+
+	DomainSeparator string `json:"domainSeparator,omitempty"`
+	StructHash      string `json:"structHash,omitempty"`
+}
+
+// TransactionBody holds the signed transaction ready to be broadcast
+type TransactionBody struct {
+	From string `json:"from"`
+	Path string `json:"path"`
+	Type string `json:"type"`
+	Hash string `json:"hash"`
+	Raw  string `json:"raw"`
+}
+
+// VanityBody describes the best address found by the vanity search
+type VanityBody struct {
+	Found    bool   `json:"found"`
+	Mnemonic string `json:"mnemonic,omitempty"`
+	Address  string `json:"address,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Score    int    `json:"score"`
+	MaxScore int    `json:"maxScore"`
+	Tried    int    `json:"tried"`
+	Total    int    `json:"total"`
+	TimedOut bool   `json:"timedOut"`
+}
+
+// AnalysisBody estimates how guessable the mnemonic is
+type AnalysisBody struct {
+	Words          int      `json:"words"`
+	DistinctWords  int      `json:"distinctWords"`
+	Period         int      `json:"period,omitempty"`
+	Sequence       int      `json:"sequence,omitempty"`
+	EntropyBits    int      `json:"entropyBits"`
+	MaxEntropyBits int      `json:"maxEntropyBits"`
+	Score          int      `json:"score"`
+	Warnings       []string `json:"warnings"`
+}
+
+// BIP85Body holds the child secret derived from the mnemonic
+type BIP85Body struct {
+	App      string `json:"app"`
+	Path     string `json:"path"`
+	Language string `json:"language,omitempty"`
+	Mnemonic string `json:"mnemonic,omitempty"`
+	Hex      string `json:"hex,omitempty"`
+	WIF      string `json:"wif,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+func errorResponse(statusCode int, err error) *Response {
+	return &Response{
+		StatusCode: statusCode,
+		Body:       ResponseBody{Error: err.Error()},
+	}
+}
+
+// addWarning appends the warning to those already in the body.
+func (body *ResponseBody) addWarning(warning string) {
+	if body.Warning != "" {
+		body.Warning += " "
+	}
+	body.Warning += warning
 }
 
 func (req *Request) AssumeDefaults() {
@@ -52,4 +273,56 @@ func (req *Request) AssumeDefaults() {
 	if req.Derivation == "" {
 		req.Derivation = DefaultDerivation
 	}
+	if req.Limit == 0 {
+		req.Limit = DefaultLookupLimit
+	}
+	if req.Accounts == 0 {
+		req.Accounts = 1
+	}
+	if req.Balance == "" {
+		req.Balance = DefaultBalance
+	}
+	if req.Format == FormatGeth && req.ChainID == 0 {
+		req.ChainID = DefaultGenesisChainID
+	}
+	if req.Format == FormatGeth && req.Fork == "" {
+		req.Fork = ForkShanghai
+	}
+	if req.Op == OpVanity && req.Family == "" {
+		req.Family = FamilySingle
+	}
+	if req.Op == OpVanity && req.Timeout == 0 {
+		req.Timeout = DefaultVanityTimeout
+	}
+	if req.Entropy != "" && req.EntropyFormat == "" {
+		req.EntropyFormat = EntropyHex
+	}
+	if req.Op == OpBIP85 && req.App == "" {
+		req.App = AppBIP39
+	}
+	if req.Op == OpBIP85 && req.Language == "" {
+		req.Language = DefaultLanguage
+	}
+	if req.Op == OpBIP85 && req.Words == 0 {
+		req.Words = DefaultPhraseLength
+	}
+	if req.Op == OpBIP85 && req.Size == 0 && req.App == AppHex {
+		req.Size = DefaultHexBytes
+	}
+	if req.Op == OpBIP85 && req.Size == 0 && req.App == AppPassword {
+		req.Size = DefaultPasswordLength
+	}
+	if req.Export == ExportKeystore && req.KDF == "" {
+		req.KDF = KDFScrypt
+	}
+}
+
+// unwrapJSON returns the JSON document held in a string, which is how doctl
+// passes objects with the -p flag. Other values are returned as they are.
+func unwrapJSON(raw json.RawMessage) json.RawMessage {
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err == nil {
+		return json.RawMessage(encoded)
+	}
+	return raw
 }
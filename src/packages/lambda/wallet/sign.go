@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// signatureV offsets the recovery id as personal_sign and ecrecover expect.
+const signatureV = 27
+
+// signMessage signs the message with the account at the index of the request
+// derivation, as EIP-191 personal_sign does.
+func signMessage(in Request) *Response {
+	if err := validateIndex(in.Index); err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	hash := accounts.TextHash([]byte(in.Message))
+	sig, err := signHash(in, hash)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, err)
+	}
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Wallet: WalletBody{
+				Mnemonic:   in.Mnemonic,
+				Derivation: in.Derivation,
+				Length:     in.Length,
+			},
+			Signature: sig,
+		},
+	}
+}
+
+// signHash signs the hash with the account at the index of the request derivation.
+func signHash(in Request, hash []byte) (*SignatureBody, error) {
+	key, err := deriveKey(in.Mnemonic, in.Password, fmt.Sprintf("%s%d", in.Derivation, in.Index), in.Strict)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += signatureV
+
+	return &SignatureBody{
+		Address:   crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		Path:      fmt.Sprintf("%s%d", in.Derivation, in.Index),
+		Hash:      hexutil.Encode(hash),
+		R:         hexutil.Encode(sig[:32]),
+		S:         hexutil.Encode(sig[32:64]),
+		V:         int(sig[crypto.RecoveryIDOffset]),
+		Signature: hexutil.Encode(sig),
+	}, nil
+}
+
+// recoverSigner returns the address which signed the message. When the address
+// is given, it also tells whether it's the signer.
+func recoverSigner(in Request) *Response {
+	if in.Op == OpVerify && !common.IsHexAddress(in.Address) {
+		return errorResponse(http.StatusBadRequest, fmt.Errorf("invalid address '%s'", in.Address))
+	}
+	sig, err := hexutil.Decode(in.Signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return errorResponse(http.StatusBadRequest, fmt.Errorf("invalid signature '%s', expected %d bytes hex", in.Signature, crypto.SignatureLength))
+	}
+	// both 0/1 and 27/28 recovery ids are in use
+	if sig[crypto.RecoveryIDOffset] >= signatureV {
+		sig[crypto.RecoveryIDOffset] -= signatureV
+	}
+
+	hash := accounts.TextHash([]byte(in.Message))
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, fmt.Errorf("cannot recover signer: %w", err))
+	}
+
+	signer := crypto.PubkeyToAddress(*pub)
+	body := &SignatureBody{
+		Address: signer.Hex(),
+		Hash:    hexutil.Encode(hash),
+	}
+	if in.Address != "" {
+		valid := common.IsHexAddress(in.Address) && common.HexToAddress(in.Address) == signer
+		body.Valid = &valid
+	}
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Signature: body,
+		},
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const helloSignature = "0xf16ea9a3478698f695fd1401bfe27e9e4a7e8e3da94aa72b021125e31fa899cc573c48ea3fe1d4ab61a9db10c19032026e3ed2dbccba5a178235ac27f94504311c"
+
+func TestSignMessage(t *testing.T) {
+	tests := map[string]struct {
+		req               *Request
+		expectedCode      int
+		expectedAddress   string
+		expectedSignature string
+		expectedV         int
+	}{
+		"first account": {
+			req:               &Request{Phrase: "test junk", Message: "hello"},
+			expectedCode:      200,
+			expectedAddress:   "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+			expectedSignature: helloSignature,
+			expectedV:         28,
+		},
+		"account index": {
+			req:               &Request{Phrase: "test junk", Message: "hello", Index: 1},
+			expectedCode:      200,
+			expectedAddress:   "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+			expectedSignature: "0x76930d64d2e5eb4b3f4572ce806eda50e1e2329d51d9ca5a713a9befcb9d20883e3d4885c3c5eaf775fc8c9fcf4882a28b582b427bc0270565f3294d935549221b",
+			expectedV:         27,
+		},
+		"negative index": {
+			req:          &Request{Phrase: "test junk", Message: "hello", Index: -1},
+			expectedCode: 400,
+		},
+		"hardened index": {
+			req:          &Request{Phrase: "test junk", Message: "hello", Index: 1 << 31},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpSign
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if test.expectedCode != 200 {
+				return
+			}
+			assert.Equal(t, test.expectedAddress, resp.Body.Signature.Address)
+			assert.Equal(t, test.expectedSignature, resp.Body.Signature.Signature)
+			assert.Equal(t, test.expectedV, resp.Body.Signature.V)
+		})
+	}
+}
+
+func TestRecoverSigner(t *testing.T) {
+	valid, invalid := true, false
+	tests := map[string]struct {
+		req             *Request
+		expectedCode    int
+		expectedAddress string
+		expectedValid   *bool
+	}{
+		"recover": {
+			req:             &Request{Op: OpRecoverSigner, Message: "hello", Signature: helloSignature},
+			expectedCode:    200,
+			expectedAddress: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		},
+		"recovery id without offset": {
+			req:             &Request{Op: OpRecoverSigner, Message: "hello", Signature: helloSignature[:len(helloSignature)-2] + "01"},
+			expectedCode:    200,
+			expectedAddress: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		},
+		"verify signer": {
+			req:             &Request{Op: OpVerify, Message: "hello", Signature: helloSignature, Address: "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266"},
+			expectedCode:    200,
+			expectedAddress: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+			expectedValid:   &valid,
+		},
+		"verify other message": {
+			req:             &Request{Op: OpVerify, Message: "hello!", Signature: helloSignature, Address: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"},
+			expectedCode:    200,
+			expectedAddress: "",
+			expectedValid:   &invalid,
+		},
+		"verify without address": {
+			req:          &Request{Op: OpVerify, Message: "hello", Signature: helloSignature},
+			expectedCode: 400,
+		},
+		"short signature": {
+			req:          &Request{Op: OpRecoverSigner, Message: "hello", Signature: helloSignature[:66]},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if resp.StatusCode != 200 {
+				return
+			}
+			if test.expectedAddress != "" {
+				assert.Equal(t, test.expectedAddress, resp.Body.Signature.Address)
+			}
+			assert.Equal(t, test.expectedValid, resp.Body.Signature.Valid)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var txTypeNames = map[uint8]string{
+	types.LegacyTxType:     TxLegacy,
+	types.AccessListTxType: TxAccessList,
+	types.DynamicFeeTxType: TxDynamicFee,
+}
+
+// signTransaction signs the transaction with the account at the index of the
+// request derivation. No node is needed, the caller provides nonce and fees.
+func signTransaction(in Request) *Response {
+	if err := validateIndex(in.Index); err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	tx, err := buildTransaction(in)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	key, err := deriveKey(in.Mnemonic, in.Password, fmt.Sprintf("%s%d", in.Derivation, in.Index), in.Strict)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, err)
+	}
+
+	signer := types.LatestSignerForChainID(big.NewInt(in.ChainID))
+	signed, err := types.SignTx(tx, signer, key)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, err)
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, err)
+	}
+	from, err := types.Sender(signer, signed)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, err)
+	}
+
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Wallet: WalletBody{
+				Mnemonic:   in.Mnemonic,
+				Derivation: in.Derivation,
+				Length:     in.Length,
+			},
+			Transaction: &TransactionBody{
+				From: from.Hex(),
+				Path: fmt.Sprintf("%s%d", in.Derivation, in.Index),
+				Type: txTypeNames[signed.Type()],
+				Hash: signed.Hash().Hex(),
+				Raw:  hexutil.Encode(raw),
+			},
+		},
+	}
+}
+
+// buildTransaction creates the unsigned transaction. Without txType given, it is
+// an EIP-1559 one when maxFeePerGas is set, an EIP-2930 one when access list is
+// set and legacy otherwise.
+func buildTransaction(in Request) (*types.Transaction, error) {
+	if in.ChainID <= 0 {
+		return nil, fmt.Errorf("chainId is required to sign a transaction")
+	}
+	if in.Gas == 0 {
+		return nil, fmt.Errorf("gas is required to sign a transaction")
+	}
+
+	var to *common.Address
+	if in.To != "" {
+		if !common.IsHexAddress(in.To) {
+			return nil, fmt.Errorf("invalid address '%s'", in.To)
+		}
+		address := common.HexToAddress(in.To)
+		to = &address
+	}
+	value, err := parseAmount("value", in.Value)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	if in.Data != "" {
+		if data, err = hexutil.Decode(in.Data); err != nil {
+			return nil, fmt.Errorf("invalid data '%s': %w", in.Data, err)
+		}
+	}
+	var accessList types.AccessList
+	if len(in.AccessList) > 0 {
+		if err := json.Unmarshal(unwrapJSON(in.AccessList), &accessList); err != nil {
+			return nil, fmt.Errorf("invalid accessList: %w", err)
+		}
+	}
+
+	txType := in.TxType
+	if txType == "" {
+		switch {
+		case in.MaxFeePerGas != "":
+			txType = TxDynamicFee
+		case accessList != nil:
+			txType = TxAccessList
+		default:
+			txType = TxLegacy
+		}
+	}
+
+	switch txType {
+	case TxLegacy:
+		if accessList != nil {
+			return nil, fmt.Errorf("legacy transaction has no access list")
+		}
+		gasPrice, err := parseAmount("gasPrice", in.GasPrice)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewTx(&types.LegacyTx{
+			Nonce: in.Nonce, GasPrice: gasPrice, Gas: in.Gas, To: to, Value: value, Data: data,
+		}), nil
+	case TxAccessList:
+		gasPrice, err := parseAmount("gasPrice", in.GasPrice)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewTx(&types.AccessListTx{
+			ChainID: big.NewInt(in.ChainID), Nonce: in.Nonce, GasPrice: gasPrice, Gas: in.Gas,
+			To: to, Value: value, Data: data, AccessList: accessList,
+		}), nil
+	case TxDynamicFee:
+		gasFeeCap, err := parseAmount("maxFeePerGas", in.MaxFeePerGas)
+		if err != nil {
+			return nil, err
+		}
+		gasTipCap, err := parseAmount("maxPriorityFeePerGas", in.MaxPriorityFeePerGas)
+		if err != nil {
+			return nil, err
+		}
+		if gasTipCap.Cmp(gasFeeCap) > 0 {
+			return nil, fmt.Errorf("maxPriorityFeePerGas %s is higher than maxFeePerGas %s", gasTipCap, gasFeeCap)
+		}
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID: big.NewInt(in.ChainID), Nonce: in.Nonce, GasTipCap: gasTipCap, GasFeeCap: gasFeeCap,
+			Gas: in.Gas, To: to, Value: value, Data: data, AccessList: accessList,
+		}), nil
+	}
+	return nil, fmt.Errorf("invalid txType '%s', accepted values: %s, %s, %s", txType, TxLegacy, TxAccessList, TxDynamicFee)
+}
+
+// parseAmount reads wei amounts given as decimal or 0x prefixed hex numbers.
+// Empty amount is zero.
+func parseAmount(name, amount string) (*big.Int, error) {
+	if amount == "" {
+		return new(big.Int), nil
+	}
+	v, ok := math.ParseBig256(amount)
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid %s '%s'", name, amount)
+	}
+	return v, nil
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// Example of the EIP-155 specification, see https://eips.ethereum.org/EIPS/eip-155
+func TestBuildTransactionSpecExample(t *testing.T) {
+	tx, err := buildTransaction(Request{
+		ChainID:  1,
+		Nonce:    9,
+		GasPrice: "20000000000",
+		Gas:      21000,
+		To:       "0x3535353535353535353535353535353535353535",
+		Value:    "0xde0b6b3a7640000",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := crypto.HexToECDSA(strings.Repeat("46", 32))
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(big.NewInt(1)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, _ := signed.MarshalBinary()
+	assert.Equal(t, "0xf86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83", hexutil.Encode(raw))
+}
+
+func TestSignTransaction(t *testing.T) {
+	tests := map[string]struct {
+		req          *Request
+		expectedCode int
+		expectedType string
+		expectedFrom string
+	}{
+		"legacy": {
+			req:          &Request{ChainID: 31337, Gas: 21000, GasPrice: "1000000000", To: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", Value: "1000000000000000000"},
+			expectedCode: 200,
+			expectedType: TxLegacy,
+			expectedFrom: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		},
+		"access list": {
+			req: &Request{ChainID: 31337, Gas: 50000, GasPrice: "1000000000", To: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+				AccessList: []byte(`"[{\"address\":\"0x70997970C51812dc3A010C7d01b50e0d17dc79C8\",\"storageKeys\":[\"0x0000000000000000000000000000000000000000000000000000000000000001\"]}]"`)},
+			expectedCode: 200,
+			expectedType: TxAccessList,
+			expectedFrom: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		},
+		"dynamic fee from other account": {
+			req:          &Request{ChainID: 1, Nonce: 7, Gas: 100000, MaxFeePerGas: "0x6fc23ac00", MaxPriorityFeePerGas: "1000000000", Data: "0xa9059cbb", To: "0x70997970C51812dc3A010C7d01b50e0d17dc79C8", Index: 2},
+			expectedCode: 200,
+			expectedType: TxDynamicFee,
+			expectedFrom: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+		},
+		"contract creation": {
+			req:          &Request{ChainID: 1, Gas: 100000, TxType: TxDynamicFee, MaxFeePerGas: "1", Data: "0x6000"},
+			expectedCode: 200,
+			expectedType: TxDynamicFee,
+			expectedFrom: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		},
+		"missing chain id": {
+			req:          &Request{Gas: 21000},
+			expectedCode: 400,
+		},
+		"missing gas": {
+			req:          &Request{ChainID: 1},
+			expectedCode: 400,
+		},
+		"tip above fee cap": {
+			req:          &Request{ChainID: 1, Gas: 21000, MaxFeePerGas: "1", MaxPriorityFeePerGas: "2"},
+			expectedCode: 400,
+		},
+		"blob transaction": {
+			req:          &Request{ChainID: 1, Gas: 21000, TxType: "eip4844"},
+			expectedCode: 400,
+		},
+		"invalid value": {
+			req:          &Request{ChainID: 1, Gas: 21000, Value: "-1"},
+			expectedCode: 400,
+		},
+		"negative index": {
+			req:          &Request{ChainID: 1, Gas: 21000, Index: -1},
+			expectedCode: 400,
+		},
+		"hardened index": {
+			req:          &Request{ChainID: 1, Gas: 21000, Index: 1 << 31},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpSignTransaction
+		test.req.Phrase = "test junk"
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if resp.StatusCode != 200 {
+				return
+			}
+			body := resp.Body.Transaction
+			assert.Equal(t, test.expectedType, body.Type)
+			assert.Equal(t, test.expectedFrom, body.From)
+
+			tx := new(types.Transaction)
+			assert.NoError(t, tx.UnmarshalBinary(hexutil.MustDecode(body.Raw)))
+			assert.Equal(t, body.Hash, tx.Hash().Hex())
+			from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(test.req.ChainID)), tx)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedFrom, from.Hex())
+		})
+	}
+}
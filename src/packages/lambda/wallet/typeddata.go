@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+const eip712Domain = "EIP712Domain"
+
+// signTypedData signs the EIP-712 payload as eth_signTypedData_v4 does.
+func signTypedData(in Request) *Response {
+	if err := validateIndex(in.Index); err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	typedData, err := parseTypedData(in.TypedData)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	hash, domainSeparator, structHash, err := hashTypedData(typedData)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+
+	sig, err := signHash(in, hash)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, err)
+	}
+	sig.DomainSeparator = hexutil.Encode(domainSeparator)
+	sig.StructHash = hexutil.Encode(structHash)
+
+	return &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Wallet: WalletBody{
+				Mnemonic:   in.Mnemonic,
+				Derivation: in.Derivation,
+				Length:     in.Length,
+			},
+			Signature: sig,
+		},
+	}
+}
+
+func parseTypedData(raw json.RawMessage) (*apitypes.TypedData, error) {
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("typedData is required to sign typed data")
+	}
+	raw = quoteChainID(unwrapJSON(raw))
+
+	typedData := &apitypes.TypedData{}
+	if err := json.Unmarshal(raw, typedData); err != nil {
+		return nil, fmt.Errorf("invalid typedData: %w", err)
+	}
+	if typedData.PrimaryType == "" {
+		return nil, fmt.Errorf("invalid typedData: primaryType is missing")
+	}
+	return typedData, nil
+}
+
+// quoteChainID turns a numeric domain chainId, as wallets send it, into the
+// string go-ethereum decodes.
+func quoteChainID(raw json.RawMessage) json.RawMessage {
+	var payload, domain map[string]json.RawMessage
+	if json.Unmarshal(raw, &payload) != nil || json.Unmarshal(payload["domain"], &domain) != nil {
+		return raw
+	}
+	chainID, ok := domain["chainId"]
+	if !ok || len(chainID) == 0 || chainID[0] == '"' {
+		return raw
+	}
+
+	domain["chainId"], _ = json.Marshal(string(chainID))
+	payload["domain"], _ = json.Marshal(domain)
+	quoted, err := json.Marshal(payload)
+	if err != nil {
+		return raw
+	}
+	return quoted
+}
+
+// hashTypedData returns keccak256("\x19\x01" ‖ domainSeparator ‖ hashStruct(message))
+// along with both of its parts.
+func hashTypedData(typedData *apitypes.TypedData) (hash, domainSeparator, structHash []byte, err error) {
+	if _, ok := typedData.Types[eip712Domain]; !ok {
+		return nil, nil, nil, fmt.Errorf("invalid typedData: types are missing %s", eip712Domain)
+	}
+	if domainSeparator, err = typedData.HashStruct(eip712Domain, typedData.Domain.Map()); err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot hash domain: %w", err)
+	}
+	if structHash, err = typedData.HashStruct(typedData.PrimaryType, typedData.Message); err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot hash message: %w", err)
+	}
+	hash = crypto.Keccak256([]byte("\x19\x01"), domainSeparator, structHash)
+	return hash, domainSeparator, structHash, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+// Example of the EIP-712 specification, see
+// https://github.com/ethereum/EIPs/blob/master/assets/eip-712/Example.js
+func TestHashTypedDataSpecExample(t *testing.T) {
+	raw, err := os.ReadFile("testdata/eip712_mail.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	typedData, err := parseTypedData(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, domainSeparator, structHash, err := hashTypedData(typedData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hexutil.Encode(domainSeparator))
+	assert.Equal(t, "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", hexutil.Encode(structHash))
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hexutil.Encode(hash))
+
+	key, _ := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	sig, err := crypto.Sign(hash, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d", hexutil.Encode(sig[:32]))
+	assert.Equal(t, "0x07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562", hexutil.Encode(sig[32:64]))
+	assert.Equal(t, byte(1), sig[64])
+}
+
+func TestSignTypedData(t *testing.T) {
+	raw, err := os.ReadFile("testdata/eip712_mail.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	asString, _ := json.Marshal(string(raw))
+
+	tests := map[string]struct {
+		req             *Request
+		expectedCode    int
+		expectedAddress string
+	}{
+		"json object": {
+			req:             &Request{Phrase: "test junk", TypedData: raw},
+			expectedCode:    200,
+			expectedAddress: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+		},
+		"json string": {
+			req:             &Request{Phrase: "test junk", TypedData: asString, Index: 2},
+			expectedCode:    200,
+			expectedAddress: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+		},
+		"missing payload": {
+			req:          &Request{Phrase: "test junk"},
+			expectedCode: 400,
+		},
+		"missing domain type": {
+			req:          &Request{Phrase: "test junk", TypedData: json.RawMessage(`{"types":{"Mail":[]},"primaryType":"Mail","message":{}}`)},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpSignTypedData
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if resp.StatusCode != 200 {
+				return
+			}
+			sig := resp.Body.Signature
+			assert.Equal(t, test.expectedAddress, sig.Address)
+			assert.Equal(t, "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", sig.DomainSeparator)
+			assert.Equal(t, "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", sig.StructHash)
+
+			signature, _ := hexutil.Decode(sig.Signature)
+			signature[64] -= signatureV
+			pub, err := crypto.SigToPub(hexutil.MustDecode(sig.Hash), signature)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedAddress, crypto.PubkeyToAddress(*pub).Hex())
+		})
+	}
+}
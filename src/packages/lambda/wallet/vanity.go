@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+var hexPattern = regexp.MustCompile(`^[0-9a-f]{0,40}$`)
+
+// vanityMatcher scores addresses by the number of matching prefix and suffix
+// characters, the pattern match adds a point.
+type vanityMatcher struct {
+	prefix  string
+	suffix  string
+	pattern *regexp.Regexp
+}
+
+type vanityCandidate struct {
+	mnemonic string
+	address  string
+	path     string
+	score    int
+}
+
+// vanitySearch looks for the mnemonic of the family whose address at any of
+// the first count indexes matches. The search ends with the first full match
+// or when the time is up, then the best match so far is returned.
+func vanitySearch(in Request) *Response {
+	if err := hasCorrectWordsLength(in.Length); err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	matcher, err := newVanityMatcher(in.Prefix, in.Suffix, in.Pattern)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err)
+	}
+	if in.Count < 1 || in.Count > MaxVanityCount {
+		return errorResponse(http.StatusBadRequest, fmt.Errorf("invalid count %d, expected 1 to %d", in.Count, MaxVanityCount))
+	}
+	if in.Timeout < 0 {
+		return errorResponse(http.StatusBadRequest, fmt.Errorf("invalid timeout %d", in.Timeout))
+	}
+	if in.Timeout > MaxVanityTimeout {
+		in.Timeout = MaxVanityTimeout
+	}
+	total, ok := familySize(in.Family, in.Length)
+	if !ok {
+		return errorResponse(http.StatusBadRequest,
+			fmt.Errorf("invalid family '%s', accepted values: %s, %s, %s", in.Family, FamilySingle, FamilyLucky, FamilyPairs))
+	}
+	paths := make([]accounts.DerivationPath, in.Count)
+	for i := range paths {
+		if paths[i], err = accounts.ParseDerivationPath(fmt.Sprintf("%s%d", in.Derivation, i)); err != nil {
+			return errorResponse(http.StatusBadRequest, err)
+		}
+	}
+
+	vanity := searchVanity(in, matcher, paths, total)
+	resp := &Response{
+		StatusCode: http.StatusOK,
+		Body: ResponseBody{
+			Wallet: WalletBody{
+				Mnemonic:   vanity.Mnemonic,
+				Derivation: in.Derivation,
+				Length:     in.Length,
+			},
+			Vanity: vanity,
+		},
+	}
+	if IsKnownWeak(vanity.Mnemonic) {
+		resp.Body.addWarning(KnownWeakWarning)
+	}
+	if !vanity.Found {
+		resp.StatusCode = http.StatusNotFound
+		resp.Body.Error = fmt.Sprintf("no full match within %d of %d mnemonics, best score %d of %d",
+			vanity.Tried, vanity.Total, vanity.Score, vanity.MaxScore)
+	}
+	return resp
+}
+
+func searchVanity(in Request, matcher *vanityMatcher, paths []accounts.DerivationPath, total int) *VanityBody {
+	var (
+		ctx, cancel = context.WithTimeout(context.Background(), time.Duration(in.Timeout)*time.Millisecond)
+		queue       = make(chan string)
+		tried       atomic.Int64
+		mu          sync.Mutex
+		wg          sync.WaitGroup
+		best        = vanityCandidate{score: -1}
+		maxScore    = matcher.maxScore()
+	)
+	defer cancel()
+
+	worker := func() {
+		defer wg.Done()
+		for mnemonic := range queue {
+			c, err := bestAddress(mnemonic, in.Password, in.Strict, paths, matcher)
+			tried.Add(1)
+			if err != nil {
+				continue
+			}
+			mu.Lock()
+			if c.score > best.score {
+				best = c
+				if best.score == maxScore {
+					cancel()
+				}
+			}
+			mu.Unlock()
+		}
+	}
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go worker()
+	}
+
+	forEachCandidate(in.Family, in.Length, func(mnemonic string) bool {
+		select {
+		case queue <- mnemonic:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+	close(queue)
+	wg.Wait()
+
+	vanity := &VanityBody{
+		Found:    best.score == maxScore,
+		Mnemonic: best.mnemonic,
+		Address:  best.address,
+		Path:     best.path,
+		Score:    best.score,
+		MaxScore: maxScore,
+		Tried:    int(tried.Load()),
+		Total:    total,
+	}
+	vanity.TimedOut = !vanity.Found && ctx.Err() == context.DeadlineExceeded
+	return vanity
+}
+
+func bestAddress(mnemonic, password string, strict bool, paths []accounts.DerivationPath, matcher *vanityMatcher) (vanityCandidate, error) {
+	wallet, err := newHDWallet(mnemonic, password, strict)
+	if err != nil {
+		return vanityCandidate{}, err
+	}
+	best := vanityCandidate{score: -1}
+	for _, path := range paths {
+		account, err := wallet.Derive(path, false)
+		if err != nil {
+			return vanityCandidate{}, err
+		}
+		address := account.Address.Hex()
+		if score := matcher.score(address); score > best.score {
+			best = vanityCandidate{mnemonic: mnemonic, address: address, path: path.String(), score: score}
+		}
+	}
+	return best, nil
+}
+
+func newVanityMatcher(prefix, suffix, pattern string) (*vanityMatcher, error) {
+	m := &vanityMatcher{
+		prefix: strings.TrimPrefix(strings.ToLower(prefix), "0x"),
+		suffix: strings.ToLower(suffix),
+	}
+	if !hexPattern.MatchString(m.prefix) {
+		return nil, fmt.Errorf("invalid prefix '%s', expected hex digits", prefix)
+	}
+	if !hexPattern.MatchString(m.suffix) {
+		return nil, fmt.Errorf("invalid suffix '%s', expected hex digits", suffix)
+	}
+	if pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+		}
+		m.pattern = re
+	}
+	if m.maxScore() == 0 {
+		return nil, fmt.Errorf("prefix, suffix or pattern is required to search vanity address")
+	}
+	return m, nil
+}
+
+func (m *vanityMatcher) maxScore() int {
+	n := len(m.prefix) + len(m.suffix)
+	if m.pattern != nil {
+		n++
+	}
+	return n
+}
+
+// score matches lowercase hex digits of the address, without 0x prefix.
+func (m *vanityMatcher) score(address string) int {
+	digits := strings.ToLower(strings.TrimPrefix(address, "0x"))
+	n := 0
+	for n < len(m.prefix) && digits[n] == m.prefix[n] {
+		n++
+	}
+	for i := 1; i <= len(m.suffix) && digits[len(digits)-i] == m.suffix[len(m.suffix)-i]; i++ {
+		n++
+	}
+	if m.pattern != nil && m.pattern.MatchString(digits) {
+		n++
+	}
+	return n
+}
+
+// familySize tells how many mnemonics the family has.
+func familySize(family string, length int) (int, bool) {
+	words := len(bip39.GetWordList())
+	switch family {
+	case FamilySingle:
+		return words, true
+	case FamilyLucky:
+		return len(luckyWords(length)), true
+	case FamilyPairs:
+		return words * (words - 1), true
+	}
+	return 0, false
+}
+
+// forEachCandidate passes mnemonics of the family to the callback until it
+// returns false. Repeated words get the last one replaced by a checksum word.
+func forEachCandidate(family string, length int, callback func(mnemonic string) bool) {
+	wordList := bip39.GetWordList()
+	pattern := func(words ...string) bool {
+		repeated, err := Repeat(strings.Join(words, " "), length)
+		if err != nil {
+			return false
+		}
+		mnemonic, err := completeChecksum(strings.Fields(repeated))
+		if err != nil {
+			return false
+		}
+		return callback(mnemonic)
+	}
+
+	switch family {
+	case FamilySingle:
+		for _, word := range wordList {
+			if !pattern(word) {
+				return
+			}
+		}
+	case FamilyLucky:
+		for _, word := range luckyWords(length) {
+			if !pattern(word) {
+				return
+			}
+		}
+	case FamilyPairs:
+		for _, first := range wordList {
+			for _, second := range wordList {
+				if first != second && !pattern(first, second) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// luckyWords are words which are checksum words of themselves repeated, so the
+// mnemonic is a single word only.
+func luckyWords(length int) []string {
+	lucky := []string{}
+	for _, word := range bip39.GetWordList() {
+		repeated, err := Repeat(word, length)
+		if err != nil {
+			return lucky
+		}
+		mnemonic, err := completeChecksum(strings.Fields(repeated))
+		if err == nil && strings.HasSuffix(mnemonic, " "+word) {
+			lucky = append(lucky, word)
+		}
+	}
+	return lucky
+}
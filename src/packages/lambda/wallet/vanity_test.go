@@ -0,0 +1,124 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLuckyWords(t *testing.T) {
+	// lists from the README
+	assert.Len(t, luckyWords(12), 130)
+	assert.Equal(t, "ahead desert dove dumb egg episode express fiction glad glass gorilla kiss leader misery mobile mother quiz rally response school sense spend stock upper usage wonder",
+		strings.Join(luckyWords(18), " "))
+	assert.Equal(t, []string{"bacon", "flag", "gas", "great", "slice", "solution", "summer", "they", "trade", "trap", "zebra"}, luckyWords(24))
+}
+
+func TestCompleteChecksum(t *testing.T) {
+	mnemonic, err := completeChecksum(strings.Fields(strings.Repeat("abandon ", 12)))
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("abandon ", 11)+"about", mnemonic)
+
+	mnemonic, err = completeChecksum(strings.Fields(strings.Repeat("zoo ", 24)))
+	assert.NoError(t, err)
+	assert.Equal(t, strings.Repeat("zoo ", 23)+"vote", mnemonic)
+}
+
+func TestVanityMatcherScore(t *testing.T) {
+	const address = "0x7ea359f97E4640b9D653C6E2726422335A0EF7bD"
+	tests := map[string]struct {
+		prefix, suffix, pattern string
+		expectedScore           int
+		expectedMax             int
+	}{
+		"full prefix":      {prefix: "0x7EA3", expectedScore: 4, expectedMax: 4},
+		"partial prefix":   {prefix: "7eb", expectedScore: 2, expectedMax: 3},
+		"prefix suffix":    {prefix: "7e", suffix: "0bd", expectedScore: 4, expectedMax: 5},
+		"pattern":          {pattern: "^7ea3.*bd$", expectedScore: 1, expectedMax: 1},
+		"pattern mismatch": {pattern: "dead", expectedScore: 0, expectedMax: 1},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			m, err := newVanityMatcher(test.prefix, test.suffix, test.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expectedScore, m.score(address))
+			assert.Equal(t, test.expectedMax, m.maxScore())
+		})
+	}
+}
+
+func TestVanitySearch(t *testing.T) {
+	tests := map[string]struct {
+		req              *Request
+		expectedCode     int
+		expectedMnemonic string
+		expectedTimedOut bool
+	}{
+		"lucky word": {
+			req:              &Request{Family: FamilyLucky, Prefix: "7ea359", Count: 1, Timeout: MaxVanityTimeout},
+			expectedCode:     200,
+			expectedMnemonic: strings.TrimSpace(strings.Repeat("action ", 12)),
+		},
+		"time is up": {
+			req:              &Request{Family: FamilyPairs, Prefix: strings.Repeat("0", 40), Count: 1, Timeout: 100},
+			expectedCode:     404,
+			expectedTimedOut: true,
+		},
+		"timeout above limit": {
+			req:              &Request{Family: FamilyPairs, Prefix: strings.Repeat("0", 40), Count: 1, Timeout: 600000},
+			expectedCode:     404,
+			expectedTimedOut: true,
+		},
+		"no pattern": {
+			req:          &Request{},
+			expectedCode: 400,
+		},
+		"invalid prefix": {
+			req:          &Request{Prefix: "0xcafez"},
+			expectedCode: 400,
+		},
+		"invalid pattern": {
+			req:          &Request{Pattern: "(dead"},
+			expectedCode: 400,
+		},
+		"negative count": {
+			req:          &Request{Prefix: "dead", Count: -1},
+			expectedCode: 400,
+		},
+		"count above limit": {
+			req:          &Request{Prefix: "dead", Count: MaxVanityCount + 1},
+			expectedCode: 400,
+		},
+		"negative timeout": {
+			req:          &Request{Prefix: "dead", Timeout: -1},
+			expectedCode: 400,
+		},
+		"unknown family": {
+			req:          &Request{Family: "triples", Prefix: "dead"},
+			expectedCode: 400,
+		},
+	}
+
+	for name, test := range tests {
+		test.req.Op = OpVanity
+		t.Run(name, func(t *testing.T) {
+			resp, err := Main(*test.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expectedCode, resp.StatusCode, resp.Body.Error)
+			if resp.StatusCode == 400 {
+				return
+			}
+			assert.Equal(t, test.expectedTimedOut, resp.Body.Vanity.TimedOut)
+			if test.expectedMnemonic != "" {
+				assert.Equal(t, test.expectedMnemonic, resp.Body.Vanity.Mnemonic)
+				assert.Equal(t, "0x7ea359f97E4640b9D653C6E2726422335A0EF7bD", resp.Body.Vanity.Address)
+			}
+		})
+	}
+}
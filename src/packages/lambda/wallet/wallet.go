@@ -1,18 +1,59 @@
 package main
 
 import (
+	"crypto/ecdsa"
 	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/btcsuite/btcutil/hdkeychain"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
 	hd "github.com/miguelmota/go-ethereum-hdwallet"
 	bip39 "github.com/tyler-smith/go-bip39"
+	"golang.org/x/text/unicode/norm"
 )
 
 func Main(in Request) (*Response, error) {
 	in.AssumeDefaults()
+	switch {
+	case in.Keystore != "":
+		return importKeystore(in), nil
+	case in.Op == OpRecoverSigner || in.Op == OpVerify:
+		return recoverSigner(in), nil
+	case in.Op == OpVanity:
+		return vanitySearch(in), nil
+	}
+
+	if in.Label != "" {
+		if err := validateLabel(in); err != nil {
+			return errorResponse(http.StatusBadRequest, err), nil
+		}
+	}
+
+	var (
+		supplied   *EntropyBody
+		transcript *MixBody
+	)
+	if in.Entropy != "" && in.MixEntropy {
+		mnemonic, mix, err := mixedMnemonic(in)
+		if err != nil {
+			return errorResponse(http.StatusBadRequest, err), nil
+		}
+		in.Mnemonic, transcript = mnemonic, mix
+	} else if in.Entropy != "" {
+		entropy, body, err := parseEntropy(in.EntropyFormat, in.Entropy, in.Length)
+		if err == nil {
+			in.Mnemonic, err = bip39.NewMnemonic(entropy)
+		}
+		if err != nil {
+			return errorResponse(http.StatusBadRequest, err), nil
+		}
+		supplied = body
+	}
+
 	if in.Mnemonic == "" && in.Phrase == "" {
 		mnemonic, err := randomMnemonic(in)
 		if err != nil {
@@ -64,14 +105,71 @@ func Main(in Request) (*Response, error) {
 		}, nil
 	}
 
-	genAccounts, err := generateAddresses(in.Mnemonic, in.Password, in.Derivation, in.Count, in.RevealPrivate)
+	resp := walletResponse(in)
+	if resp.StatusCode == http.StatusOK && IsKnownWeak(in.Mnemonic) {
+		resp.Body.addWarning(KnownWeakWarning)
+	}
+	if resp.StatusCode == http.StatusOK && in.Label != "" {
+		resp.Body.addWarning(InsecureLabelWarning)
+	}
+	if resp.StatusCode == http.StatusOK {
+		resp.Body.Entropy, resp.Body.Mix = supplied, transcript
+	}
+	return resp, nil
+}
+
+// walletResponse runs the operation on the valid mnemonic.
+func walletResponse(in Request) *Response {
+	switch in.Op {
+	case OpLookup:
+		return lookupAddress(in)
+	case OpRecoverPassphrase:
+		return recoverPassphrase(in)
+	case OpSign:
+		return signMessage(in)
+	case OpSignTypedData:
+		return signTypedData(in)
+	case OpSignTransaction:
+		return signTransaction(in)
+	case OpAnalyze:
+		return analyzeMnemonic(in)
+	case OpBIP85:
+		return deriveBIP85(in)
+	}
+
+	if in.Export != "" {
+		if err := validateExport(in); err != nil {
+			return errorResponse(http.StatusBadRequest, err)
+		}
+	}
+	if in.Format != "" {
+		if err := validateFormat(in); err != nil {
+			return errorResponse(http.StatusBadRequest, err)
+		}
+	}
+
+	keys, err := deriveKeys(in.Mnemonic, in.Password, in.Derivation, in.Count, in.Strict)
 	if err != nil {
 		return &Response{
 			StatusCode: http.StatusInternalServerError,
 			Body: ResponseBody{
 				Error: err.Error(),
 			},
-		}, nil
+		}
+	}
+	genAccounts := accountsOf(keys, in.RevealPrivate)
+
+	if in.Export == ExportKeystore {
+		if err := exportKeystores(genAccounts, keys, in.KeystorePassword, in.KDF); err != nil {
+			return errorResponse(http.StatusInternalServerError, err)
+		}
+	}
+
+	var output string
+	if in.Format != "" {
+		if output, err = outputFormats[in.Format](in, genAccounts, keys); err != nil {
+			return errorResponse(http.StatusInternalServerError, err)
+		}
 	}
 
 	return &Response{
@@ -83,15 +181,17 @@ func Main(in Request) (*Response, error) {
 				Length:     in.Length,
 			},
 			Accounts: genAccounts,
+			Output:   output,
 		},
-	}, nil
+	}
 }
 
 func randomMnemonic(in Request) (string, error) {
 	entropyBits := in.Length*11 - in.Length/3
 	entropy := make([]byte, entropyBits/8)
-	_, err := rand.Read(entropy)
-	if err != nil {
+	if in.Label != "" && insecureLabels {
+		labelEntropy(in.Label, entropy)
+	} else if _, err := rand.Read(entropy); err != nil {
 		return "", err
 	}
 
@@ -120,16 +220,37 @@ func constructFromPhrase(phrase string, length int) (string, error) {
 }
 
 func generateAddresses(mnemonic, password, derivation string, count int, includePrivate bool) ([]AccountBody, error) {
-	wallet, err := hd.NewFromMnemonic(mnemonic, password)
+	keys, err := deriveKeys(mnemonic, password, derivation, count, false)
 	if err != nil {
 		return nil, err
 	}
+	return accountsOf(keys, includePrivate), nil
+}
 
-	accs := make([]AccountBody, count)
-	//fmt.Println("Generating accs:", "count", count)
-	for i := 0; i < count; i++ {
-		prePath := fmt.Sprintf("%s%d", derivation, i)
-		path, err := accounts.ParseDerivationPath(prePath)
+// accountsOf lists the addresses of the keys, with the keys themselves when
+// includePrivate is set.
+func accountsOf(keys []*ecdsa.PrivateKey, includePrivate bool) []AccountBody {
+	accs := make([]AccountBody, len(keys))
+	for i, key := range keys {
+		accs[i].Address = crypto.PubkeyToAddress(key.PublicKey).Hex()
+		if includePrivate {
+			accs[i].PublicKey = hex.EncodeToString(crypto.FromECDSAPub(&key.PublicKey)[1:])
+			accs[i].PrivateKey = hex.EncodeToString(crypto.FromECDSA(key))
+		}
+	}
+	return accs
+}
+
+// deriveKeys returns private keys of the first count accounts.
+func deriveKeys(mnemonic, password, derivation string, count int, strict bool) ([]*ecdsa.PrivateKey, error) {
+	wallet, err := newHDWallet(mnemonic, password, strict)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*ecdsa.PrivateKey, count)
+	for i := range keys {
+		path, err := accounts.ParseDerivationPath(fmt.Sprintf("%s%d", derivation, i))
 		if err != nil {
 			return nil, err
 		}
@@ -137,16 +258,64 @@ func generateAddresses(mnemonic, password, derivation string, count int, include
 		if err != nil {
 			return nil, err
 		}
-		accs[i].Address = account.Address.Hex()
-		if includePrivate {
-			pub, _ := wallet.PublicKeyHex(account)
-			priv, _ := wallet.PrivateKeyHex(account)
-			accs[i].PublicKey = pub
-			accs[i].PrivateKey = priv
+		if keys[i], err = wallet.PrivateKey(account); err != nil {
+			return nil, err
 		}
 	}
+	return keys, nil
+}
 
-	return accs, nil
+// deriveKey returns the private key of the account at the path.
+func deriveKey(mnemonic, password, path string, strict bool) (*ecdsa.PrivateKey, error) {
+	wallet, err := newHDWallet(mnemonic, password, strict)
+	if err != nil {
+		return nil, err
+	}
+	derivationPath, err := accounts.ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	account, err := wallet.Derive(derivationPath, false)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.PrivateKey(account)
+}
+
+// validateIndex checks the account index is a non-hardened BIP-32 child.
+func validateIndex(index int) error {
+	if index < 0 || int64(index) >= int64(hdkeychain.HardenedKeyStart) {
+		return fmt.Errorf("invalid index %d, expected 0 to %d", index, hdkeychain.HardenedKeyStart-1)
+	}
+	return nil
+}
+
+// newHDWallet normalizes the mnemonic and password to NFKD as BIP-39 requires,
+// go-bip39 hashes both strings verbatim.
+func newHDWallet(mnemonic, password string, strict bool) (*hd.Wallet, error) {
+	seed, err := newSeed(mnemonic, password)
+	if err != nil {
+		return nil, err
+	}
+	return walletFromSeed(seed, strict)
+}
+
+// newSeed returns the BIP-39 seed of NFKD normalized mnemonic and password.
+func newSeed(mnemonic, password string) ([]byte, error) {
+	return bip39.NewSeedWithErrorChecking(norm.NFKD.String(mnemonic), norm.NFKD.String(password))
+}
+
+// walletFromSeed creates a wallet of the seed. By default it keeps the legacy
+// derivation of btcutil issue #172, which is wrong for about 1 in 256 hardened
+// child keys, so addresses of existing wallets don't change. Strict wallets
+// derive keys as BIP-32 specifies.
+func walletFromSeed(seed []byte, strict bool) (*hd.Wallet, error) {
+	wallet, err := hd.NewFromSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+	wallet.SetFixIssue172(strict)
+	return wallet, nil
 }
 
 func parseMnemonic(mnemonic string) (string, int, error) {
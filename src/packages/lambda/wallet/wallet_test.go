@@ -36,6 +36,7 @@ func TestKeyDerivation(t *testing.T) {
 							Address: "0x7a307954D1337af50c00Aa0e2Dbe92Dd9CcfBA80",
 						},
 					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
@@ -83,6 +84,7 @@ func TestKeyDerivation(t *testing.T) {
 							Address: "0xb24069bCeE29200FAbadaBf4e8f96E3EDb05b257",
 						},
 					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
@@ -104,6 +106,7 @@ func TestKeyDerivation(t *testing.T) {
 							Address: "0xC634fB51Ee91E771066737fbd483e5EF8b6275AE",
 						},
 					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
@@ -125,6 +128,7 @@ func TestKeyDerivation(t *testing.T) {
 							Address: "0x7d347F41F826d8d95A41e41298Dbdc60fa3435C4",
 						},
 					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
@@ -152,6 +156,7 @@ func TestKeyDerivation(t *testing.T) {
 							Address: "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
 						},
 					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
@@ -180,6 +185,79 @@ func TestKeyDerivation(t *testing.T) {
 							Address: "0x64ffA20464c6dF3b23f1540327578eBf10C23785",
 						},
 					},
+					Warning: KnownWeakWarning,
+				},
+			},
+		},
+		"test junk phrase with full-width password": {
+			req: &Request{
+				Phrase:   "test junk",
+				Password: "ｐａｓｓｗｏｒｄ",
+				Count:    2,
+			},
+			expectedResponse: &Response{
+				StatusCode: 200,
+				Body: ResponseBody{
+					Wallet: WalletBody{
+						Derivation: DefaultDerivation,
+						Length:     DefaultPhraseLength,
+						Mnemonic:   "test test test test test test test test test test test junk",
+					},
+					Accounts: []AccountBody{
+						{
+							Address: "0xfaFfA9053ac6c6315Aa7806d1336F10F9b280Ee9",
+						},
+						{
+							Address: "0xb1a3B55051E04d44Ce457A6A479c999557521921",
+						},
+					},
+					Warning: KnownWeakWarning,
+				},
+			},
+		},
+		"test junk phrase with decomposed accents password": {
+			req: &Request{
+				Phrase:   "test junk",
+				Password: "pa\u0308sswo\u0308rd",
+				Count:    1,
+			},
+			expectedResponse: &Response{
+				StatusCode: 200,
+				Body: ResponseBody{
+					Wallet: WalletBody{
+						Derivation: DefaultDerivation,
+						Length:     DefaultPhraseLength,
+						Mnemonic:   "test test test test test test test test test test test junk",
+					},
+					Accounts: []AccountBody{
+						{
+							Address: "0x3B8bd3EE1014b2d6039b1fb478d108405187D12f",
+						},
+					},
+					Warning: KnownWeakWarning,
+				},
+			},
+		},
+		"test junk phrase with composed accents password": {
+			req: &Request{
+				Phrase:   "test junk",
+				Password: "pässwörd",
+				Count:    1,
+			},
+			expectedResponse: &Response{
+				StatusCode: 200,
+				Body: ResponseBody{
+					Wallet: WalletBody{
+						Derivation: DefaultDerivation,
+						Length:     DefaultPhraseLength,
+						Mnemonic:   "test test test test test test test test test test test junk",
+					},
+					Accounts: []AccountBody{
+						{
+							Address: "0x3B8bd3EE1014b2d6039b1fb478d108405187D12f",
+						},
+					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
@@ -201,6 +279,7 @@ func TestKeyDerivation(t *testing.T) {
 							Address: "0xC81E455a82d2029E2ecDdFaA6365B15CD69589a5",
 						},
 					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
@@ -222,6 +301,7 @@ func TestKeyDerivation(t *testing.T) {
 							Address: "0x523063b46e87d419c4b30402170C3ED91dCefD6A",
 						},
 					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
@@ -243,6 +323,7 @@ func TestKeyDerivation(t *testing.T) {
 							Address: "0x588D620acE82cC864976bD3Cfb44Fdb33DCe0ED4",
 						},
 					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
@@ -264,6 +345,7 @@ func TestKeyDerivation(t *testing.T) {
 							Address: "0xa601FAb390f54318642F2e5f9fe4584F7502A769",
 						},
 					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
@@ -285,6 +367,7 @@ func TestKeyDerivation(t *testing.T) {
 							Address: "0x5cD325FeeefaBc5f91C856c71d46a923F9235cE4",
 						},
 					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
@@ -306,6 +389,7 @@ func TestKeyDerivation(t *testing.T) {
 							Address: "0x11aaa3bfdc8c6669002fb74ABbc33adf4b7cfb92",
 						},
 					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
@@ -335,6 +419,7 @@ func TestKeyDerivation(t *testing.T) {
 							PrivateKey: "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d",
 						},
 					},
+					Warning: KnownWeakWarning,
 				},
 			},
 		},
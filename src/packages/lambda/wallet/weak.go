@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// knownCycle is the longest repeated pattern treated as weak. Patterns
+	// of up to 3 words with the last word are 44 bits at most, few hours of
+	// brute force. They are checked by the structure, a filter of the 2^33
+	// patterns would not fit the function.
+	knownCycle = 3
+
+	KnownWeakWarning = "WARNING: this mnemonic is a short repeated pattern or a published example, anyone can derive its keys. Never use it to store real funds!"
+)
+
+// publicMnemonics are default mnemonics of popular development tools.
+var publicMnemonics = []string{
+	"test test test test test test test test test test test junk",
+	"myth like bonus scare over problem client lizard pioneer submit female collect",
+	"candy maple cake sugar pudding cream honey rich smooth crumble sweet treat",
+}
+
+// vectorMnemonics are the BIP-39 test vectors, except those Repeat makes.
+var vectorMnemonics = []string{
+	"legal winner thank year wave sausage worth useful legal winner thank yellow",
+	"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+	"legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal will",
+	"letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always",
+	"legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth title",
+	"letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic bless",
+	"jelly better achieve collect unaware mountain thought cargo oxygen act hood bridge",
+	"renew stay biology evidence goat welcome casual join adapt armor shuffle fault little machine walk stumble urge swap",
+	"dignity pass list indicate nasty swamp pool script soccer toe leaf photo multiply desk host tomato cradle drill spread actor shine dismiss champion exotic",
+	"afford alter spike radar gate glance object seek swamp infant panel yellow",
+	"indicate race push merry suffer human cruise dwarf pole review arch keep canvas theme poem divorce alter left",
+	"clutch control vehicle tonight unusual clog visa ice plunge glimpse recipe series open hour vintage deposit universe tip job dress radar refuse motion taste",
+	"turtle front uncle idea crush write shrug there lottery flower risk shell",
+	"kiss carry display unusual confirm curtain upgrade antique rotate hello void custom frequent obey nut hole price segment",
+	"exile ask congress lamp submit jacket era scheme attend cousin alcohol catch course end lucky hurt sentence oven short ball bird grab wing top",
+	"board flee heavy tunnel powder denial science ski answer betray cargo cat",
+	"board blade invite damage undo sun mimic interest slam gaze truly inherit resist great inject rocket museum chief",
+	"beyond stage sleep clip because twist token leaf atom beauty genius food business side grid unable middle armed observe pair crouch tonight away coconut",
+}
+
+// knownWeak is the exact set of publicMnemonics and vectorMnemonics.
+var knownWeak = func() map[string]bool {
+	known := map[string]bool{}
+	for _, mnemonic := range append(publicMnemonics, vectorMnemonics...) {
+		known[mnemonic] = true
+	}
+	return known
+}()
+
+// IsKnownWeak tells whether the mnemonic is weak: one of the listed published
+// phrases or any phrase Repeat makes of a short pattern, whatever the last
+// word is. Other phrases are not checked against any corpus.
+func IsKnownWeak(mnemonic string) bool {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	if len(words) == 0 {
+		return false
+	}
+	if period := repetitionPeriod(words[:len(words)-1]); period > 0 && period <= knownCycle {
+		return true
+	}
+	return knownWeak[strings.Join(words, " ")]
+}
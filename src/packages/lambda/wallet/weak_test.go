@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+func TestKnownWeakCoversSamples(t *testing.T) {
+	mnemonics := append([]string{}, publicMnemonics...)
+	for _, length := range []int{12, 15, 18, 21, 24} {
+		for _, word := range bip39.GetWordList() {
+			repeated, _ := Repeat(word, length)
+			mnemonic, err := completeChecksum(strings.Fields(repeated))
+			if err != nil {
+				t.Fatal("Cannot complete checksum", "word", word, err)
+			}
+			mnemonics = append(mnemonics, mnemonic)
+		}
+	}
+	vectors := map[string][][4]string{}
+	readJSON(t, TrezorVectorsFile, &vectors)
+	for _, vector := range vectors["english"] {
+		mnemonics = append(mnemonics, vector[1])
+	}
+
+	for _, mnemonic := range mnemonics {
+		assert.True(t, bip39.IsMnemonicValid(mnemonic), "mnemonic is not valid", mnemonic)
+		assert.True(t, IsKnownWeak(mnemonic), "mnemonic is not known weak", mnemonic)
+	}
+	assert.Len(t, knownWeak, len(publicMnemonics)+len(vectorMnemonics), "duplicate mnemonics")
+}
+
+func TestIsKnownWeak(t *testing.T) {
+	tests := map[string]struct {
+		mnemonic string
+		expected bool
+	}{
+		"single word sample":  {"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", true},
+		"other last word":     {"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon absurd", true},
+		"three words pattern": {"alien alert action alien alert action alien alert action alien alert ankle", true},
+		"hardhat":             {"test test test test test test test test test test test junk", true},
+		"ganache":             {"myth like bonus scare over problem client lizard pioneer submit female collect", true},
+		"test vector":         {"letter advice cage absurd amount doctor acoustic avoid letter advice cage above", true},
+		"extra spaces":        {"  candy maple cake sugar pudding cream honey rich smooth crumble sweet   treat ", true},
+		"random":              {"mechanic bounce spell stomach stuff shoulder reveal upgrade useful kit ranch monster", false},
+		"long pattern":        {"alien alert action actor alien alert action actor alien alert action adjust", false},
+		"random 24 words":     {"pave route ahead keen dizzy rally strategy ozone amused merit sauce jeans ocean wet oblige bright motor spin solid evolve frown rule fuel rescue", false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsKnownWeak(test.mnemonic))
+		})
+	}
+}
+
+func TestKnownWeakWarning(t *testing.T) {
+	resp, err := Main(Request{Phrase: "test junk", Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, KnownWeakWarning, resp.Body.Warning)
+
+	resp, err = Main(Request{Count: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, resp.Body.Warning)
+}
+
+func TestWarningsAreKept(t *testing.T) {
+	body := ResponseBody{}
+	body.addWarning(KnownWeakWarning)
+	body.addWarning(InsecureLabelWarning)
+	assert.Equal(t, KnownWeakWarning+" "+InsecureLabelWarning, body.Warning)
+}